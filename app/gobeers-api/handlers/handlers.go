@@ -4,8 +4,10 @@ package handlers
 import (
 	"net/http"
 	"os"
+	"time"
 
 	v1 "github.com/phbpx/gobeers/app/gobeers-api/handlers/v1"
+	"github.com/phbpx/gobeers/business/sys/blob"
 	"github.com/phbpx/gobeers/business/web/v1/mid"
 	"github.com/phbpx/gobeers/foundation/web"
 	"github.com/uptrace/bun"
@@ -15,10 +17,13 @@ import (
 
 // APIMuxConfig contains all the mandatory systems required by handlers.
 type APIMuxConfig struct {
-	Shutdown chan os.Signal
-	Log      *zap.SugaredLogger
-	DB       *bun.DB
-	Tracer   trace.Tracer
+	Shutdown    chan os.Signal
+	Log         *zap.SugaredLogger
+	DB          *bun.DB
+	Tracer      trace.Tracer
+	CursorKey   string
+	TrendingTTL time.Duration
+	Images      blob.Store
 }
 
 // APIMux constructs a http.Handler with all application routes defined.
@@ -34,8 +39,11 @@ func APIMux(cfg APIMuxConfig) http.Handler {
 
 	// Load the v1 routes.
 	v1.Routes(app, v1.Config{
-		Log: cfg.Log,
-		DB:  cfg.DB,
+		Log:         cfg.Log,
+		DB:          cfg.DB,
+		CursorKey:   cfg.CursorKey,
+		TrendingTTL: cfg.TrendingTTL,
+		Images:      cfg.Images,
 	})
 
 	return app
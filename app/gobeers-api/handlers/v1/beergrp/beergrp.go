@@ -7,43 +7,94 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/phbpx/gobeers/business/core/beer"
+	"github.com/phbpx/gobeers/business/sys/cursor"
+	"github.com/phbpx/gobeers/business/sys/order"
+	"github.com/phbpx/gobeers/business/web/auth"
 	v1Web "github.com/phbpx/gobeers/business/web/v1"
 	"github.com/phbpx/gobeers/foundation/web"
 )
 
 const (
-	defaultPage = 1
-	defaultSize = 10
+	defaultPage         = 1
+	defaultSize         = 10
+	defaultSuggestLimit = 10
+	defaultSimilarLimit = 10
+	defaultTrendingSize = 10
+	defaultWindow       = "7d"
 )
 
 // Handlers manages the set of beer endpoints.
 type Handlers struct {
-	Beer beer.Core
+	Beer   beer.Core
+	Cursor cursor.Signer
 }
 
-// Create adds a new beer to the system.
+// beerRefResponse is the response of a request answered by another beer,
+// pointing at it.
+type beerRefResponse struct {
+	Error  string `json:"error"`
+	BeerID string `json:"beer_id"`
+}
+
+// Create adds a new beer to the system. A duplicate of an existing beer is
+// rejected with a 409 pointing at it, unless an admin sets force=true.
 func (h Handlers) Create(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	force := web.Query(r, "force", false)
+	forceBool, err := strconv.ParseBool(force)
+	if err != nil {
+		return v1Web.NewRequestError(fmt.Errorf("invalid force format, force[%s]", force), http.StatusBadRequest)
+	}
+
+	if forceBool {
+		if err := requireAdmin(ctx, r); err != nil {
+			return err
+		}
+	}
+
 	var nb beer.NewBeer
 	if err := web.Decode(r, &nb); err != nil {
 		return fmt.Errorf("unable to decode payload: %w", err)
 	}
 
-	b, err := h.Beer.Create(ctx, nb)
+	b, err := h.Beer.Create(ctx, nb, forceBool)
 	if err != nil {
-		return fmt.Errorf("creating new beer, nb[%+v]: %w", nb, err)
+		var de *beer.DuplicateError
+		switch {
+		case errors.As(err, &de):
+			return respondDuplicate(ctx, w, de)
+		default:
+			return fmt.Errorf("creating new beer, nb[%+v]: %w", nb, err)
+		}
 	}
 
 	return web.Respond(ctx, w, b, http.StatusCreated)
 }
 
-// QueryByID returns a beer by its ID.
+// QueryByID returns a beer by its ID. Admins can set include_deleted=true to
+// get a deleted beer.
 func (h Handlers) QueryByID(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	includeDeleted, err := parseIncludeDeleted(ctx, r)
+	if err != nil {
+		return err
+	}
+
 	id := web.Param(r, "id")
-	b, err := h.Beer.QueryByID(ctx, id)
+
+	query := h.Beer.QueryByID
+	if includeDeleted {
+		query = h.Beer.QueryByIDWithDeleted
+	}
+
+	b, err := query(ctx, id)
 	if err != nil {
+		var me *beer.MergedError
 		switch {
+		case errors.As(err, &me):
+			return respondMerged(ctx, w, r, me)
 		case errors.Is(err, beer.ErrInvalidID):
 			return v1Web.NewRequestError(err, http.StatusBadRequest)
 		case errors.Is(err, beer.ErrNotFound):
@@ -56,6 +107,193 @@ func (h Handlers) QueryByID(ctx context.Context, w http.ResponseWriter, r *http.
 	return web.Respond(ctx, w, b, http.StatusOK)
 }
 
+// QueryStats returns the review aggregates of a beer, including the per
+// section averages of the review scoresheets.
+func (h Handlers) QueryStats(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	id := web.Param(r, "id")
+	stats, err := h.Beer.QueryStats(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, beer.ErrInvalidID):
+			return v1Web.NewRequestError(err, http.StatusBadRequest)
+		case errors.Is(err, beer.ErrNotFound):
+			return v1Web.NewRequestError(err, http.StatusNotFound)
+		default:
+			return fmt.Errorf("querying stats ID[%s]: %w", id, err)
+		}
+	}
+
+	return web.Respond(ctx, w, stats, http.StatusOK)
+}
+
+// QuerySimilar returns the beers with the closest attributes to a beer, each
+// with its similarity score.
+func (h Handlers) QuerySimilar(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	limit := web.Query(r, "limit", defaultSimilarLimit)
+	limitNumber, err := strconv.Atoi(limit)
+	if err != nil {
+		return v1Web.NewRequestError(fmt.Errorf("invalid limit format, limit[%s]", limit), http.StatusBadRequest)
+	}
+
+	id := web.Param(r, "id")
+	similar, err := h.Beer.QuerySimilar(ctx, id, limitNumber)
+	if err != nil {
+		switch {
+		case errors.Is(err, beer.ErrInvalidID):
+			return v1Web.NewRequestError(err, http.StatusBadRequest)
+		case errors.Is(err, beer.ErrNotFound):
+			return v1Web.NewRequestError(err, http.StatusNotFound)
+		default:
+			return fmt.Errorf("querying similar beers ID[%s]: %w", id, err)
+		}
+	}
+
+	return web.Respond(ctx, w, similar, http.StatusOK)
+}
+
+// AddImage uploads an image of a beer, sent in the image field of a
+// multipart form.
+func (h Handlers) AddImage(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	v, err := web.GetValues(ctx)
+	if err != nil {
+		return web.NewShutdownError("web value missing from context")
+	}
+
+	file, err := web.DecodeFile(w, r, "image", beer.MaxImageSize, beer.ImageContentTypes()...)
+	if err != nil {
+		switch {
+		case errors.Is(err, web.ErrFileTooLarge):
+			return v1Web.NewRequestError(err, http.StatusRequestEntityTooLarge)
+		case errors.Is(err, web.ErrUnsupportedMediaType):
+			return v1Web.NewRequestError(err, http.StatusUnsupportedMediaType)
+		default:
+			return v1Web.NewRequestError(err, http.StatusBadRequest)
+		}
+	}
+
+	id := web.Param(r, "id")
+	ni := beer.NewImage{
+		ContentType: file.ContentType,
+		Data:        file.Data,
+	}
+
+	image, err := h.Beer.AddImage(ctx, id, ni, v.Now)
+	if err != nil {
+		switch {
+		case errors.Is(err, beer.ErrInvalidID):
+			return v1Web.NewRequestError(err, http.StatusBadRequest)
+		case errors.Is(err, beer.ErrNotFound):
+			return v1Web.NewRequestError(err, http.StatusNotFound)
+		default:
+			return fmt.Errorf("adding image ID[%s]: %w", id, err)
+		}
+	}
+
+	return web.Respond(ctx, w, image, http.StatusCreated)
+}
+
+// Update updates a beer in the system.
+func (h Handlers) Update(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	var ub beer.UpdateBeer
+	if err := web.Decode(r, &ub); err != nil {
+		return fmt.Errorf("unable to decode payload: %w", err)
+	}
+
+	id := web.Param(r, "id")
+
+	b, err := h.Beer.Update(ctx, id, ub)
+	if err != nil {
+		var de *beer.DuplicateError
+		var me *beer.MergedError
+		switch {
+		case errors.As(err, &me):
+			return respondMerged(ctx, w, r, me)
+		case errors.Is(err, beer.ErrInvalidID):
+			return v1Web.NewRequestError(err, http.StatusBadRequest)
+		case errors.Is(err, beer.ErrNotFound):
+			return v1Web.NewRequestError(err, http.StatusNotFound)
+		case errors.As(err, &de):
+			return respondDuplicate(ctx, w, de)
+		default:
+			return fmt.Errorf("updating beer ID[%s], ub[%+v]: %w", id, ub, err)
+		}
+	}
+
+	return web.Respond(ctx, w, b, http.StatusOK)
+}
+
+// Merge merges a duplicate beer into the beer, leaving a redirect to the
+// beer in place of the duplicate.
+func (h Handlers) Merge(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	v, err := web.GetValues(ctx)
+	if err != nil {
+		return web.NewShutdownError("web value missing from context")
+	}
+
+	var mb beer.MergeBeer
+	if err := web.Decode(r, &mb); err != nil {
+		return fmt.Errorf("unable to decode payload: %w", err)
+	}
+
+	id := web.Param(r, "id")
+
+	b, err := h.Beer.Merge(ctx, id, mb, v.Now)
+	if err != nil {
+		switch {
+		case errors.Is(err, beer.ErrInvalidID):
+			return v1Web.NewRequestError(err, http.StatusBadRequest)
+		case errors.Is(err, beer.ErrNotFound):
+			return v1Web.NewRequestError(beer.ErrNotFound, http.StatusNotFound)
+		default:
+			return fmt.Errorf("merging beer ID[%s], mb[%+v]: %w", id, mb, err)
+		}
+	}
+
+	return web.Respond(ctx, w, b, http.StatusOK)
+}
+
+// Delete removes a beer from the system. It can be restored until it's
+// purged.
+func (h Handlers) Delete(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	id := web.Param(r, "id")
+
+	if err := h.Beer.Delete(ctx, id); err != nil {
+		switch {
+		case errors.Is(err, beer.ErrInvalidID):
+			return v1Web.NewRequestError(err, http.StatusBadRequest)
+		case errors.Is(err, beer.ErrNotFound):
+			return v1Web.NewRequestError(err, http.StatusNotFound)
+		default:
+			return fmt.Errorf("deleting beer ID[%s]: %w", id, err)
+		}
+	}
+
+	return web.Respond(ctx, w, nil, http.StatusNoContent)
+}
+
+// Restore brings back a deleted beer.
+func (h Handlers) Restore(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	id := web.Param(r, "id")
+
+	b, err := h.Beer.Restore(ctx, id)
+	if err != nil {
+		var de *beer.DuplicateError
+		switch {
+		case errors.As(err, &de):
+			return respondDuplicate(ctx, w, de)
+		case errors.Is(err, beer.ErrInvalidID):
+			return v1Web.NewRequestError(err, http.StatusBadRequest)
+		case errors.Is(err, beer.ErrNotFound):
+			return v1Web.NewRequestError(err, http.StatusNotFound)
+		default:
+			return fmt.Errorf("restoring beer ID[%s]: %w", id, err)
+		}
+	}
+
+	return web.Respond(ctx, w, b, http.StatusOK)
+}
+
+// Query returns a list of beers with paging.
 func (h Handlers) Query(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 	page := web.Query(r, "page", defaultPage)
 	pageNumber, err := strconv.Atoi(page)
@@ -69,18 +307,162 @@ func (h Handlers) Query(ctx context.Context, w http.ResponseWriter, r *http.Requ
 		return v1Web.NewRequestError(fmt.Errorf("invalid rows format, size[%s]", size), http.StatusBadRequest)
 	}
 
-	list, err := h.Beer.Query(ctx, pageNumber, sizeNumber)
+	filter, err := parseFilter(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	orderBy, err := order.Parse(web.Query(r, "orderBy", ""), beer.DefaultBeerOrderBy)
 	if err != nil {
-		return fmt.Errorf("querying beers: %w", err)
+		return v1Web.NewRequestError(err, http.StatusBadRequest)
+	}
+
+	if r.URL.Query().Has("cursor") {
+		cur, err := h.decodeCursor(r, orderBy)
+		if err != nil {
+			return err
+		}
+
+		list, page, err := h.Beer.QueryByCursor(ctx, filter, cur, sizeNumber)
+		if err != nil {
+			switch {
+			case errors.Is(err, order.ErrInvalidField):
+				return v1Web.NewRequestError(err, http.StatusBadRequest)
+			default:
+				return fmt.Errorf("querying beers by cursor: %w", err)
+			}
+		}
+
+		return h.respondCursor(ctx, w, r, list, len(list), page)
+	}
+
+	list, err := h.Beer.Query(ctx, filter, orderBy, pageNumber, sizeNumber)
+	if err != nil {
+		switch {
+		case errors.Is(err, order.ErrInvalidField):
+			return v1Web.NewRequestError(err, http.StatusBadRequest)
+		default:
+			return fmt.Errorf("querying beers: %w", err)
+		}
 	}
 
 	if len(list) == 0 {
 		return web.Respond(ctx, w, nil, http.StatusNoContent)
 	}
 
+	v1Web.SetPageLinks(w, r, pageNumber, sizeNumber, len(list))
+
 	return web.Respond(ctx, w, list, http.StatusOK)
 }
 
+// Search returns the beers matching a full text search, ranked by relevance.
+func (h Handlers) Search(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	page := web.Query(r, "page", defaultPage)
+	pageNumber, err := strconv.Atoi(page)
+	if err != nil {
+		return v1Web.NewRequestError(fmt.Errorf("invalid page format, page[%s]", page), http.StatusBadRequest)
+	}
+
+	size := web.Query(r, "size", defaultSize)
+	sizeNumber, err := strconv.Atoi(size)
+	if err != nil {
+		return v1Web.NewRequestError(fmt.Errorf("invalid rows format, size[%s]", size), http.StatusBadRequest)
+	}
+
+	q := web.Query(r, "q", "")
+
+	results, err := h.Beer.Search(ctx, q, pageNumber, sizeNumber)
+	if err != nil {
+		return fmt.Errorf("searching beers q[%s]: %w", q, err)
+	}
+
+	if len(results) == 0 {
+		return web.Respond(ctx, w, nil, http.StatusNoContent)
+	}
+
+	v1Web.SetPageLinks(w, r, pageNumber, sizeNumber, len(results))
+
+	return web.Respond(ctx, w, results, http.StatusOK)
+}
+
+// Top returns the best rated beers, optionally filtered by style or brewery.
+func (h Handlers) Top(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	page := web.Query(r, "page", defaultPage)
+	pageNumber, err := strconv.Atoi(page)
+	if err != nil {
+		return v1Web.NewRequestError(fmt.Errorf("invalid page format, page[%s]", page), http.StatusBadRequest)
+	}
+
+	size := web.Query(r, "size", defaultSize)
+	sizeNumber, err := strconv.Atoi(size)
+	if err != nil {
+		return v1Web.NewRequestError(fmt.Errorf("invalid rows format, size[%s]", size), http.StatusBadRequest)
+	}
+
+	filter, err := parseFilter(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	top, err := h.Beer.QueryTop(ctx, filter, pageNumber, sizeNumber)
+	if err != nil {
+		return fmt.Errorf("querying top beers: %w", err)
+	}
+
+	if len(top) == 0 {
+		return web.Respond(ctx, w, nil, http.StatusNoContent)
+	}
+
+	v1Web.SetPageLinks(w, r, pageNumber, sizeNumber, len(top))
+
+	return web.Respond(ctx, w, top, http.StatusOK)
+}
+
+// Trending returns the beers trending over a window of time, like 7d or 12h.
+func (h Handlers) Trending(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	v, err := web.GetValues(ctx)
+	if err != nil {
+		return web.NewShutdownError("web value missing from context")
+	}
+
+	window := web.Query(r, "window", defaultWindow)
+	windowDuration, err := parseWindow(window)
+	if err != nil {
+		return v1Web.NewRequestError(fmt.Errorf("invalid window format, window[%s]", window), http.StatusBadRequest)
+	}
+
+	limit := web.Query(r, "limit", defaultTrendingSize)
+	limitNumber, err := strconv.Atoi(limit)
+	if err != nil {
+		return v1Web.NewRequestError(fmt.Errorf("invalid limit format, limit[%s]", limit), http.StatusBadRequest)
+	}
+
+	trending, err := h.Beer.QueryTrending(ctx, windowDuration, limitNumber, v.Now)
+	if err != nil {
+		return fmt.Errorf("querying trending beers window[%s]: %w", window, err)
+	}
+
+	return web.Respond(ctx, w, trending, http.StatusOK)
+}
+
+// Suggest returns the beer names and breweries matching a typeahead prefix.
+func (h Handlers) Suggest(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	limit := web.Query(r, "limit", defaultSuggestLimit)
+	limitNumber, err := strconv.Atoi(limit)
+	if err != nil {
+		return v1Web.NewRequestError(fmt.Errorf("invalid limit format, limit[%s]", limit), http.StatusBadRequest)
+	}
+
+	prefix := web.Query(r, "prefix", "")
+
+	suggestions, err := h.Beer.Suggest(ctx, prefix, limitNumber)
+	if err != nil {
+		return fmt.Errorf("suggesting beers prefix[%s]: %w", prefix, err)
+	}
+
+	return web.Respond(ctx, w, suggestions, http.StatusOK)
+}
+
 // CreateReview adds a new review to an existing beer.
 func (h Handlers) CreateReview(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 	v, err := web.GetValues(ctx)
@@ -93,15 +475,26 @@ func (h Handlers) CreateReview(ctx context.Context, w http.ResponseWriter, r *ht
 		return fmt.Errorf("unable to decode payload: %w", err)
 	}
 
+	mode := web.Query(r, "upsert", false)
+	upsert, err := strconv.ParseBool(mode)
+	if err != nil {
+		return v1Web.NewRequestError(fmt.Errorf("invalid upsert format, upsert[%s]", mode), http.StatusBadRequest)
+	}
+
 	id := web.Param(r, "id")
+	claims := auth.GetClaims(ctx)
 
-	rw, err := h.Beer.CreateReview(ctx, id, nr, v.Now)
+	rw, err := h.Beer.CreateReview(ctx, claims.PersonID, id, nr, upsert, v.Now)
 	if err != nil {
 		switch {
+		case errors.Is(err, beer.ErrDuplicateReview):
+			return v1Web.NewRequestError(err, http.StatusConflict)
 		case errors.Is(err, beer.ErrInvalidID):
 			return v1Web.NewRequestError(err, http.StatusBadRequest)
 		case errors.Is(err, beer.ErrNotFound):
 			return v1Web.NewRequestError(err, http.StatusNotFound)
+		case errors.Is(err, beer.ErrForbidden):
+			return v1Web.NewRequestError(err, http.StatusForbidden)
 		default:
 			return fmt.Errorf("creating review ID[%s], nr[%+v]: %w", id, nr, err)
 		}
@@ -110,6 +503,78 @@ func (h Handlers) CreateReview(ctx context.Context, w http.ResponseWriter, r *ht
 	return web.Respond(ctx, w, rw, http.StatusCreated)
 }
 
+// UpdateReview updates a review written by the authenticated user.
+func (h Handlers) UpdateReview(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	var ur beer.UpdateReview
+	if err := web.Decode(r, &ur); err != nil {
+		return fmt.Errorf("unable to decode payload: %w", err)
+	}
+
+	id := web.Param(r, "id")
+	rid := web.Param(r, "rid")
+	claims := auth.GetClaims(ctx)
+
+	rw, err := h.Beer.UpdateReview(ctx, claims.PersonID, id, rid, ur)
+	if err != nil {
+		switch {
+		case errors.Is(err, beer.ErrInvalidID):
+			return v1Web.NewRequestError(err, http.StatusBadRequest)
+		case errors.Is(err, beer.ErrReviewNotFound):
+			return v1Web.NewRequestError(err, http.StatusNotFound)
+		case errors.Is(err, beer.ErrForbidden):
+			return v1Web.NewRequestError(err, http.StatusForbidden)
+		default:
+			return fmt.Errorf("updating review ID[%s] RID[%s], ur[%+v]: %w", id, rid, ur, err)
+		}
+	}
+
+	return web.Respond(ctx, w, rw, http.StatusOK)
+}
+
+// DeleteReview removes a review written by the authenticated user.
+func (h Handlers) DeleteReview(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	id := web.Param(r, "id")
+	rid := web.Param(r, "rid")
+	claims := auth.GetClaims(ctx)
+
+	if err := h.Beer.DeleteReview(ctx, claims.PersonID, id, rid); err != nil {
+		switch {
+		case errors.Is(err, beer.ErrInvalidID):
+			return v1Web.NewRequestError(err, http.StatusBadRequest)
+		case errors.Is(err, beer.ErrReviewNotFound):
+			return v1Web.NewRequestError(err, http.StatusNotFound)
+		case errors.Is(err, beer.ErrForbidden):
+			return v1Web.NewRequestError(err, http.StatusForbidden)
+		default:
+			return fmt.Errorf("deleting review ID[%s] RID[%s]: %w", id, rid, err)
+		}
+	}
+
+	return web.Respond(ctx, w, nil, http.StatusNoContent)
+}
+
+// RestoreReview brings back a deleted review.
+func (h Handlers) RestoreReview(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	id := web.Param(r, "id")
+	rid := web.Param(r, "rid")
+
+	rw, err := h.Beer.RestoreReview(ctx, id, rid)
+	if err != nil {
+		switch {
+		case errors.Is(err, beer.ErrInvalidID):
+			return v1Web.NewRequestError(err, http.StatusBadRequest)
+		case errors.Is(err, beer.ErrNotFound), errors.Is(err, beer.ErrReviewNotFound):
+			return v1Web.NewRequestError(err, http.StatusNotFound)
+		case errors.Is(err, beer.ErrDuplicateReview):
+			return v1Web.NewRequestError(err, http.StatusConflict)
+		default:
+			return fmt.Errorf("restoring review ID[%s] RID[%s]: %w", id, rid, err)
+		}
+	}
+
+	return web.Respond(ctx, w, rw, http.StatusOK)
+}
+
 // QueryReviews returns all reviews for a beer.
 func (h Handlers) QueryReviews(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 	id := web.Param(r, "id")
@@ -126,9 +591,37 @@ func (h Handlers) QueryReviews(ctx context.Context, w http.ResponseWriter, r *ht
 		return v1Web.NewRequestError(fmt.Errorf("invalid rows format, size[%s]", size), http.StatusBadRequest)
 	}
 
-	reviews, err := h.Beer.QueryReviews(ctx, id, pageNumber, sizeNumber)
+	orderBy, err := order.Parse(web.Query(r, "orderBy", ""), beer.DefaultReviewOrderBy)
+	if err != nil {
+		return v1Web.NewRequestError(err, http.StatusBadRequest)
+	}
+
+	if r.URL.Query().Has("cursor") {
+		cur, err := h.decodeCursor(r, orderBy)
+		if err != nil {
+			return err
+		}
+
+		reviews, page, err := h.Beer.QueryReviewsByCursor(ctx, id, cur, sizeNumber)
+		if err != nil {
+			switch {
+			case errors.Is(err, order.ErrInvalidField):
+				return v1Web.NewRequestError(err, http.StatusBadRequest)
+			case errors.Is(err, beer.ErrInvalidID):
+				return v1Web.NewRequestError(err, http.StatusBadRequest)
+			default:
+				return fmt.Errorf("querying reviews by cursor ID[%s]: %w", id, err)
+			}
+		}
+
+		return h.respondCursor(ctx, w, r, reviews, len(reviews), page)
+	}
+
+	reviews, err := h.Beer.QueryReviews(ctx, id, orderBy, pageNumber, sizeNumber)
 	if err != nil {
 		switch {
+		case errors.Is(err, order.ErrInvalidField):
+			return v1Web.NewRequestError(err, http.StatusBadRequest)
 		case errors.Is(err, beer.ErrInvalidID):
 			return v1Web.NewRequestError(err, http.StatusBadRequest)
 		case errors.Is(err, beer.ErrNotFound):
@@ -142,5 +635,170 @@ func (h Handlers) QueryReviews(ctx context.Context, w http.ResponseWriter, r *ht
 		return web.Respond(ctx, w, nil, http.StatusNoContent)
 	}
 
+	v1Web.SetPageLinks(w, r, pageNumber, sizeNumber, len(reviews))
+
 	return web.Respond(ctx, w, reviews, http.StatusOK)
 }
+
+// decodeCursor returns the cursor sent in the request. An empty cursor starts
+// paging from the beginning using the requested ordering, otherwise the
+// ordering stored in the cursor is used.
+func (h Handlers) decodeCursor(r *http.Request, orderBy []order.By) (cursor.Cursor, error) {
+	token := r.URL.Query().Get("cursor")
+	if token == "" {
+		return cursor.Cursor{OrderBy: orderBy}, nil
+	}
+
+	cur, err := h.Cursor.Decode(token)
+	if err != nil {
+		return cursor.Cursor{}, v1Web.NewRequestError(err, http.StatusBadRequest)
+	}
+
+	return cur, nil
+}
+
+// respondCursor sends a page of items along with the cursors around it.
+func (h Handlers) respondCursor(ctx context.Context, w http.ResponseWriter, r *http.Request, items any, count int, page cursor.Page) error {
+	if count == 0 {
+		return web.Respond(ctx, w, nil, http.StatusNoContent)
+	}
+
+	resp, err := v1Web.NewCursorResponse(w, r, h.Cursor, items, page)
+	if err != nil {
+		return fmt.Errorf("building cursor response: %w", err)
+	}
+
+	return web.Respond(ctx, w, resp, http.StatusOK)
+}
+
+// ReportReview adds a report from the authenticated user on a review.
+func (h Handlers) ReportReview(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	v, err := web.GetValues(ctx)
+	if err != nil {
+		return web.NewShutdownError("web value missing from context")
+	}
+
+	var nr beer.NewReport
+	if err := web.Decode(r, &nr); err != nil {
+		return fmt.Errorf("unable to decode payload: %w", err)
+	}
+
+	rid := web.Param(r, "rid")
+	claims := auth.GetClaims(ctx)
+
+	report, err := h.Beer.ReportReview(ctx, claims.PersonID, rid, nr, v.Now)
+	if err != nil {
+		switch {
+		case errors.Is(err, beer.ErrInvalidID):
+			return v1Web.NewRequestError(err, http.StatusBadRequest)
+		case errors.Is(err, beer.ErrReviewNotFound):
+			return v1Web.NewRequestError(err, http.StatusNotFound)
+		case errors.Is(err, beer.ErrForbidden):
+			return v1Web.NewRequestError(err, http.StatusForbidden)
+		case errors.Is(err, beer.ErrDuplicateReport):
+			return v1Web.NewRequestError(err, http.StatusConflict)
+		default:
+			return fmt.Errorf("reporting review RID[%s], nr[%+v]: %w", rid, nr, err)
+		}
+	}
+
+	return web.Respond(ctx, w, report, http.StatusCreated)
+}
+
+// VoteReview records whether the authenticated user found a review helpful.
+func (h Handlers) VoteReview(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	v, err := web.GetValues(ctx)
+	if err != nil {
+		return web.NewShutdownError("web value missing from context")
+	}
+
+	var nv beer.NewVote
+	if err := web.Decode(r, &nv); err != nil {
+		return fmt.Errorf("unable to decode payload: %w", err)
+	}
+
+	rid := web.Param(r, "rid")
+	claims := auth.GetClaims(ctx)
+
+	rw, err := h.Beer.VoteReview(ctx, claims.PersonID, rid, nv, v.Now)
+	if err != nil {
+		switch {
+		case errors.Is(err, beer.ErrInvalidID):
+			return v1Web.NewRequestError(err, http.StatusBadRequest)
+		case errors.Is(err, beer.ErrReviewNotFound):
+			return v1Web.NewRequestError(err, http.StatusNotFound)
+		case errors.Is(err, beer.ErrForbidden):
+			return v1Web.NewRequestError(err, http.StatusForbidden)
+		default:
+			return fmt.Errorf("voting review RID[%s], nv[%+v]: %w", rid, nv, err)
+		}
+	}
+
+	return web.Respond(ctx, w, rw, http.StatusOK)
+}
+
+// parseWindow parses a window of time expressed in days, like 7d, or as a
+// duration, like 12h.
+func parseWindow(window string) (time.Duration, error) {
+	if strings.HasSuffix(window, "d") {
+		n, err := strconv.Atoi(strings.TrimSuffix(window, "d"))
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+
+	return time.ParseDuration(window)
+}
+
+// requireAdmin authenticates the request on its own, for the parameters of
+// public routes only admins are allowed to set.
+func requireAdmin(ctx context.Context, r *http.Request) error {
+	claims, err := auth.Authenticate(ctx, r.Header.Get("authorization"))
+	if err != nil {
+		return auth.NewAuthError("authenticate: failed: %s", err)
+	}
+
+	if !claims.Authorized(auth.RoleAdmin) {
+		return v1Web.NewRequestError(beer.ErrForbidden, http.StatusForbidden)
+	}
+
+	return nil
+}
+
+// respondDuplicate responds a conflict with the existing beer, which can be
+// found at the Location header.
+func respondDuplicate(ctx context.Context, w http.ResponseWriter, de *beer.DuplicateError) error {
+	resp := beerRefResponse{
+		Error:  beer.ErrDuplicateBeer.Error(),
+		BeerID: de.BeerID,
+	}
+
+	w.Header().Set("Location", "/v1/beers/"+de.BeerID)
+
+	return web.Respond(ctx, w, resp, http.StatusConflict)
+}
+
+// respondMerged redirects permanently to the beer the requested beer was
+// merged into. Requests other than GET and HEAD get a 308 so clients repeat
+// them with the same method and body.
+func respondMerged(ctx context.Context, w http.ResponseWriter, r *http.Request, me *beer.MergedError) error {
+	status := http.StatusMovedPermanently
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		status = http.StatusPermanentRedirect
+	}
+
+	location := "/v1/beers/" + me.BeerID
+	if r.URL.RawQuery != "" {
+		location += "?" + r.URL.RawQuery
+	}
+
+	resp := beerRefResponse{
+		Error:  beer.ErrBeerMerged.Error(),
+		BeerID: me.BeerID,
+	}
+
+	w.Header().Set("Location", location)
+
+	return web.Respond(ctx, w, resp, status)
+}
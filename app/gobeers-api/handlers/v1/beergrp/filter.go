@@ -0,0 +1,121 @@
+package beergrp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/phbpx/gobeers/business/core/beer"
+	v1Web "github.com/phbpx/gobeers/business/web/v1"
+)
+
+// parseFilter binds the query string parameters of the request into a
+// beer.QueryFilter. Values that can't be parsed are reported as request
+// errors.
+func parseFilter(ctx context.Context, r *http.Request) (beer.QueryFilter, error) {
+	values := r.URL.Query()
+
+	var filter beer.QueryFilter
+
+	if v := values.Get("style"); v != "" {
+		filter.Style = &v
+	}
+
+	if v := values.Get("brewery"); v != "" {
+		filter.Brewery = &v
+	}
+
+	if v := values.Get("brewery_id"); v != "" {
+		filter.BreweryID = &v
+	}
+
+	if v := values.Get("name"); v != "" {
+		filter.Name = &v
+	}
+
+	if v := values.Get("tags"); v != "" {
+		filter.Tags = strings.Split(v, ",")
+	}
+
+	filter.TagMatch = values.Get("tag_match")
+
+	var err error
+
+	if filter.IncludeDeleted, err = parseIncludeDeleted(ctx, r); err != nil {
+		return beer.QueryFilter{}, err
+	}
+
+	if filter.MinABV, err = parseFloat(values.Get("min_abv"), "min_abv"); err != nil {
+		return beer.QueryFilter{}, err
+	}
+
+	if filter.MaxABV, err = parseFloat(values.Get("max_abv"), "max_abv"); err != nil {
+		return beer.QueryFilter{}, err
+	}
+
+	if filter.MinScore, err = parseFloat(values.Get("min_score"), "min_score"); err != nil {
+		return beer.QueryFilter{}, err
+	}
+
+	if filter.CreatedAfter, err = parseTime(values.Get("created_after"), "created_after"); err != nil {
+		return beer.QueryFilter{}, err
+	}
+
+	if filter.CreatedBefore, err = parseTime(values.Get("created_before"), "created_before"); err != nil {
+		return beer.QueryFilter{}, err
+	}
+
+	return filter, nil
+}
+
+// parseIncludeDeleted reads the include_deleted parameter, which only admins
+// are allowed to set.
+func parseIncludeDeleted(ctx context.Context, r *http.Request) (bool, error) {
+	v := r.URL.Query().Get("include_deleted")
+	if v == "" {
+		return false, nil
+	}
+
+	includeDeleted, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, v1Web.NewRequestError(fmt.Errorf("invalid include_deleted format, include_deleted[%s]", v), http.StatusBadRequest)
+	}
+
+	if includeDeleted {
+		if err := requireAdmin(ctx, r); err != nil {
+			return false, err
+		}
+	}
+
+	return includeDeleted, nil
+}
+
+func parseFloat(v string, key string) (*float32, error) {
+	if v == "" {
+		return nil, nil
+	}
+
+	f, err := strconv.ParseFloat(v, 32)
+	if err != nil {
+		return nil, v1Web.NewRequestError(fmt.Errorf("invalid %s format, %s[%s]", key, key, v), http.StatusBadRequest)
+	}
+
+	f32 := float32(f)
+	return &f32, nil
+}
+
+func parseTime(v string, key string) (*time.Time, error) {
+	if v == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return nil, v1Web.NewRequestError(fmt.Errorf("invalid %s format, %s[%s]", key, key, v), http.StatusBadRequest)
+	}
+
+	return &t, nil
+}
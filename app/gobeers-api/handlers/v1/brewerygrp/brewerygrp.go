@@ -0,0 +1,142 @@
+// Package brewerygrp maintains the group of handlers for brewery access.
+package brewerygrp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/phbpx/gobeers/business/core/brewery"
+	v1Web "github.com/phbpx/gobeers/business/web/v1"
+	"github.com/phbpx/gobeers/foundation/web"
+)
+
+const (
+	defaultPage = 1
+	defaultSize = 10
+)
+
+// Handlers manages the set of brewery endpoints.
+type Handlers struct {
+	Brewery brewery.Core
+}
+
+// Create adds a new brewery to the system.
+func (h Handlers) Create(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	v, err := web.GetValues(ctx)
+	if err != nil {
+		return web.NewShutdownError("web value missing from context")
+	}
+
+	var nb brewery.NewBrewery
+	if err := web.Decode(r, &nb); err != nil {
+		return fmt.Errorf("unable to decode payload: %w", err)
+	}
+
+	b, err := h.Brewery.Create(ctx, nb, v.Now)
+	if err != nil {
+		switch {
+		case errors.Is(err, brewery.ErrUniqueName):
+			return v1Web.NewRequestError(err, http.StatusConflict)
+		default:
+			return fmt.Errorf("creating new brewery, nb[%+v]: %w", nb, err)
+		}
+	}
+
+	return web.Respond(ctx, w, b, http.StatusCreated)
+}
+
+// Update updates a brewery in the system.
+func (h Handlers) Update(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	var ub brewery.UpdateBrewery
+	if err := web.Decode(r, &ub); err != nil {
+		return fmt.Errorf("unable to decode payload: %w", err)
+	}
+
+	id := web.Param(r, "id")
+
+	b, err := h.Brewery.Update(ctx, id, ub)
+	if err != nil {
+		switch {
+		case errors.Is(err, brewery.ErrInvalidID):
+			return v1Web.NewRequestError(err, http.StatusBadRequest)
+		case errors.Is(err, brewery.ErrNotFound):
+			return v1Web.NewRequestError(err, http.StatusNotFound)
+		case errors.Is(err, brewery.ErrUniqueName):
+			return v1Web.NewRequestError(err, http.StatusConflict)
+		default:
+			return fmt.Errorf("updating brewery ID[%s], ub[%+v]: %w", id, ub, err)
+		}
+	}
+
+	return web.Respond(ctx, w, b, http.StatusOK)
+}
+
+// Delete removes a brewery from the system.
+func (h Handlers) Delete(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	id := web.Param(r, "id")
+
+	if err := h.Brewery.Delete(ctx, id); err != nil {
+		switch {
+		case errors.Is(err, brewery.ErrInvalidID):
+			return v1Web.NewRequestError(err, http.StatusBadRequest)
+		case errors.Is(err, brewery.ErrNotFound):
+			return v1Web.NewRequestError(err, http.StatusNotFound)
+		case errors.Is(err, brewery.ErrHasBeers):
+			return v1Web.NewRequestError(err, http.StatusConflict)
+		default:
+			return fmt.Errorf("deleting brewery ID[%s]: %w", id, err)
+		}
+	}
+
+	return web.Respond(ctx, w, nil, http.StatusNoContent)
+}
+
+// QueryByID returns a brewery by its ID.
+func (h Handlers) QueryByID(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	id := web.Param(r, "id")
+
+	b, err := h.Brewery.QueryByID(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, brewery.ErrInvalidID):
+			return v1Web.NewRequestError(err, http.StatusBadRequest)
+		case errors.Is(err, brewery.ErrNotFound):
+			return v1Web.NewRequestError(err, http.StatusNotFound)
+		default:
+			return fmt.Errorf("ID[%s]: %w", id, err)
+		}
+	}
+
+	return web.Respond(ctx, w, b, http.StatusOK)
+}
+
+// Query returns a list of breweries with paging.
+func (h Handlers) Query(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	page := web.Query(r, "page", defaultPage)
+	pageNumber, err := strconv.Atoi(page)
+	if err != nil {
+		return v1Web.NewRequestError(fmt.Errorf("invalid page format, page[%s]", page), http.StatusBadRequest)
+	}
+
+	size := web.Query(r, "size", defaultSize)
+	sizeNumber, err := strconv.Atoi(size)
+	if err != nil {
+		return v1Web.NewRequestError(fmt.Errorf("invalid rows format, size[%s]", size), http.StatusBadRequest)
+	}
+
+	list, err := h.Brewery.Query(ctx, pageNumber, sizeNumber)
+	if err != nil {
+		return fmt.Errorf("querying breweries: %w", err)
+	}
+
+	if len(list) == 0 {
+		return web.Respond(ctx, w, nil, http.StatusNoContent)
+	}
+
+	v1Web.SetPageLinks(w, r, pageNumber, sizeNumber, len(list))
+
+	return web.Respond(ctx, w, list, http.StatusOK)
+}
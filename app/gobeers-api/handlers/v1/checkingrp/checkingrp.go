@@ -0,0 +1,93 @@
+// Package checkingrp maintains the group of handlers for check-in access.
+package checkingrp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/phbpx/gobeers/business/core/checkin"
+	"github.com/phbpx/gobeers/business/sys/cursor"
+	"github.com/phbpx/gobeers/business/sys/order"
+	"github.com/phbpx/gobeers/business/web/auth"
+	v1Web "github.com/phbpx/gobeers/business/web/v1"
+	"github.com/phbpx/gobeers/foundation/web"
+)
+
+const defaultSize = 10
+
+// Handlers manages the set of check-in endpoints.
+type Handlers struct {
+	Checkin checkin.Core
+	Cursor  cursor.Signer
+}
+
+// Create adds a check-in of the authenticated user.
+func (h Handlers) Create(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	v, err := web.GetValues(ctx)
+	if err != nil {
+		return web.NewShutdownError("web value missing from context")
+	}
+
+	var nc checkin.NewCheckin
+	if err := web.Decode(r, &nc); err != nil {
+		return fmt.Errorf("unable to decode payload: %w", err)
+	}
+
+	claims := auth.GetClaims(ctx)
+
+	ci, err := h.Checkin.Create(ctx, claims.PersonID, nc, v.Now)
+	if err != nil {
+		switch {
+		case errors.Is(err, checkin.ErrForbidden):
+			return v1Web.NewRequestError(err, http.StatusForbidden)
+		default:
+			return fmt.Errorf("creating checkin, nc[%+v]: %w", nc, err)
+		}
+	}
+
+	return web.Respond(ctx, w, ci, http.StatusCreated)
+}
+
+// QueryTimeline returns the check-ins of a user with cursor paging.
+func (h Handlers) QueryTimeline(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	id := web.Param(r, "id")
+
+	size := web.Query(r, "size", defaultSize)
+	sizeNumber, err := strconv.Atoi(size)
+	if err != nil {
+		return v1Web.NewRequestError(fmt.Errorf("invalid rows format, size[%s]", size), http.StatusBadRequest)
+	}
+
+	cur := cursor.Cursor{OrderBy: []order.By{checkin.TimelineOrderBy}}
+	if token := r.URL.Query().Get("cursor"); token != "" {
+		if cur, err = h.Cursor.Decode(token); err != nil {
+			return v1Web.NewRequestError(err, http.StatusBadRequest)
+		}
+	}
+
+	checkins, page, err := h.Checkin.QueryTimelineByCursor(ctx, id, cur, sizeNumber)
+	if err != nil {
+		switch {
+		case errors.Is(err, checkin.ErrInvalidID):
+			return v1Web.NewRequestError(err, http.StatusBadRequest)
+		case errors.Is(err, cursor.ErrInvalid):
+			return v1Web.NewRequestError(err, http.StatusBadRequest)
+		default:
+			return fmt.Errorf("querying checkins ID[%s]: %w", id, err)
+		}
+	}
+
+	if len(checkins) == 0 {
+		return web.Respond(ctx, w, nil, http.StatusNoContent)
+	}
+
+	resp, err := v1Web.NewCursorResponse(w, r, h.Cursor, checkins, page)
+	if err != nil {
+		return fmt.Errorf("building cursor response: %w", err)
+	}
+
+	return web.Respond(ctx, w, resp, http.StatusOK)
+}
@@ -0,0 +1,54 @@
+// Package imagegrp maintains the group of handlers serving the stored images.
+package imagegrp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/phbpx/gobeers/business/sys/blob"
+	v1Web "github.com/phbpx/gobeers/business/web/v1"
+	"github.com/phbpx/gobeers/foundation/web"
+)
+
+// Handlers manages the set of image endpoints.
+type Handlers struct {
+	Images blob.Store
+}
+
+// QueryByKey streams the image stored under the key. Images never change
+// once stored, so clients may cache them for good.
+func (h Handlers) QueryByKey(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	key := web.Param(r, "key")
+
+	obj, err := h.Images.Get(ctx, key)
+	if err != nil {
+		switch {
+		case errors.Is(err, blob.ErrInvalidKey):
+			return v1Web.NewRequestError(err, http.StatusBadRequest)
+		case errors.Is(err, blob.ErrNotFound):
+			return v1Web.NewRequestError(err, http.StatusNotFound)
+		default:
+			return fmt.Errorf("getting image key[%s]: %w", key, err)
+		}
+	}
+	defer obj.Body.Close()
+
+	web.SetStatusCode(ctx, http.StatusOK)
+
+	w.Header().Set("Content-Type", obj.ContentType)
+	w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	if obj.Size > 0 {
+		w.Header().Set("Content-Length", strconv.FormatInt(obj.Size, 10))
+	}
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := io.Copy(w, obj.Body); err != nil {
+		return fmt.Errorf("writing image key[%s]: %w", key, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+// Package moderationgrp maintains the group of handlers for review moderation.
+package moderationgrp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/phbpx/gobeers/business/core/beer"
+	"github.com/phbpx/gobeers/business/web/auth"
+	v1Web "github.com/phbpx/gobeers/business/web/v1"
+	"github.com/phbpx/gobeers/foundation/web"
+)
+
+const (
+	defaultPage = 1
+	defaultSize = 10
+)
+
+// reviewResponse is the view of a review moderators get, which includes the
+// reports and moderation details left out of the public reviews.
+type reviewResponse struct {
+	beer.Review
+	ReportCount      int        `json:"report_count"`
+	ModerationReason string     `json:"moderation_reason,omitempty"`
+	ModeratedBy      string     `json:"moderated_by,omitempty"`
+	ModeratedAt      *time.Time `json:"moderated_at,omitempty"`
+}
+
+func toReviewResponse(r beer.Review) reviewResponse {
+	return reviewResponse{
+		Review:           r,
+		ReportCount:      r.ReportCount,
+		ModerationReason: r.ModerationReason,
+		ModeratedBy:      r.ModeratedBy,
+		ModeratedAt:      r.ModeratedAt,
+	}
+}
+
+// Handlers manages the set of moderation endpoints.
+type Handlers struct {
+	Beer beer.Core
+}
+
+// Queue returns the reviews waiting for a moderator with paging.
+func (h Handlers) Queue(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	page := web.Query(r, "page", defaultPage)
+	pageNumber, err := strconv.Atoi(page)
+	if err != nil {
+		return v1Web.NewRequestError(fmt.Errorf("invalid page format, page[%s]", page), http.StatusBadRequest)
+	}
+
+	size := web.Query(r, "size", defaultSize)
+	sizeNumber, err := strconv.Atoi(size)
+	if err != nil {
+		return v1Web.NewRequestError(fmt.Errorf("invalid rows format, size[%s]", size), http.StatusBadRequest)
+	}
+
+	reviews, err := h.Beer.QueryModerationQueue(ctx, pageNumber, sizeNumber)
+	if err != nil {
+		return fmt.Errorf("unable to query moderation queue: %w", err)
+	}
+
+	if len(reviews) == 0 {
+		return web.Respond(ctx, w, nil, http.StatusNoContent)
+	}
+
+	resp := make([]reviewResponse, len(reviews))
+	for i, review := range reviews {
+		resp[i] = toReviewResponse(review)
+	}
+
+	v1Web.SetPageLinks(w, r, pageNumber, sizeNumber, len(reviews))
+
+	return web.Respond(ctx, w, resp, http.StatusOK)
+}
+
+// Approve publishes a review from the moderation queue.
+func (h Handlers) Approve(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	return h.decide(ctx, w, r, h.Beer.ApproveReview)
+}
+
+// Reject takes down a review from the moderation queue.
+func (h Handlers) Reject(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	return h.decide(ctx, w, r, h.Beer.RejectReview)
+}
+
+// decideFunc is the signature shared by the moderation decisions.
+type decideFunc func(ctx context.Context, moderatorID string, reviewID string, md beer.ModerationDecision, now time.Time) (beer.Review, error)
+
+// decide applies a moderator decision to the review in the request.
+func (h Handlers) decide(ctx context.Context, w http.ResponseWriter, r *http.Request, fn decideFunc) error {
+	v, err := web.GetValues(ctx)
+	if err != nil {
+		return web.NewShutdownError("web value missing from context")
+	}
+
+	// The decision body is optional.
+	var md beer.ModerationDecision
+	if err := web.Decode(r, &md); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("unable to decode payload: %w", err)
+	}
+
+	rid := web.Param(r, "rid")
+	claims := auth.GetClaims(ctx)
+
+	review, err := fn(ctx, claims.PersonID, rid, md, v.Now)
+	if err != nil {
+		switch {
+		case errors.Is(err, beer.ErrInvalidID):
+			return v1Web.NewRequestError(err, http.StatusBadRequest)
+		case errors.Is(err, beer.ErrReviewNotFound):
+			return v1Web.NewRequestError(err, http.StatusNotFound)
+		case errors.Is(err, beer.ErrForbidden):
+			return v1Web.NewRequestError(err, http.StatusForbidden)
+		default:
+			return fmt.Errorf("moderating review RID[%s], md[%+v]: %w", rid, md, err)
+		}
+	}
+
+	return web.Respond(ctx, w, toReviewResponse(review), http.StatusOK)
+}
@@ -0,0 +1,46 @@
+// Package recommendationgrp maintains the group of handlers for the beers
+// recommended to the authenticated user.
+package recommendationgrp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/phbpx/gobeers/business/core/recommendation"
+	"github.com/phbpx/gobeers/business/web/auth"
+	v1Web "github.com/phbpx/gobeers/business/web/v1"
+	"github.com/phbpx/gobeers/foundation/web"
+)
+
+const defaultLimit = 10
+
+// Handlers manages the set of recommendation endpoints.
+type Handlers struct {
+	Recommendation recommendation.Core
+}
+
+// Query returns the beers recommended to the user, best first.
+func (h Handlers) Query(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	limit := web.Query(r, "limit", defaultLimit)
+	limitNumber, err := strconv.Atoi(limit)
+	if err != nil {
+		return v1Web.NewRequestError(fmt.Errorf("invalid limit format, limit[%s]", limit), http.StatusBadRequest)
+	}
+
+	claims := auth.GetClaims(ctx)
+
+	recs, err := h.Recommendation.Query(ctx, claims.PersonID, limitNumber)
+	if err != nil {
+		switch {
+		case errors.Is(err, recommendation.ErrForbidden):
+			return v1Web.NewRequestError(err, http.StatusForbidden)
+		default:
+			return fmt.Errorf("unable to query recommendations: %w", err)
+		}
+	}
+
+	return web.Respond(ctx, w, recs, http.StatusOK)
+}
@@ -0,0 +1,45 @@
+// Package stylegrp maintains the group of handlers for style taxonomy access.
+package stylegrp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/phbpx/gobeers/business/core/style"
+	v1Web "github.com/phbpx/gobeers/business/web/v1"
+	"github.com/phbpx/gobeers/foundation/web"
+)
+
+// Handlers manages the set of style endpoints.
+type Handlers struct {
+	Style style.Core
+}
+
+// Query returns the full style taxonomy as a tree.
+func (h Handlers) Query(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	styles, err := h.Style.Query(ctx)
+	if err != nil {
+		return fmt.Errorf("querying styles: %w", err)
+	}
+
+	return web.Respond(ctx, w, styles, http.StatusOK)
+}
+
+// QueryByID returns a style, along with its children, by its BJCP code.
+func (h Handlers) QueryByID(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	id := web.Param(r, "id")
+
+	st, err := h.Style.QueryByID(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, style.ErrNotFound):
+			return v1Web.NewRequestError(err, http.StatusNotFound)
+		default:
+			return fmt.Errorf("ID[%s]: %w", id, err)
+		}
+	}
+
+	return web.Respond(ctx, w, st, http.StatusOK)
+}
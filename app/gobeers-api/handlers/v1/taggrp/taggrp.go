@@ -0,0 +1,172 @@
+// Package taggrp maintains the group of handlers for tag access.
+package taggrp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/phbpx/gobeers/business/core/tag"
+	v1Web "github.com/phbpx/gobeers/business/web/v1"
+	"github.com/phbpx/gobeers/foundation/web"
+)
+
+const (
+	defaultPage       = 1
+	defaultSize       = 10
+	defaultCloudLimit = 50
+)
+
+// Handlers manages the set of tag endpoints.
+type Handlers struct {
+	Tag tag.Core
+}
+
+// Create adds a new curated tag to the system.
+func (h Handlers) Create(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	v, err := web.GetValues(ctx)
+	if err != nil {
+		return web.NewShutdownError("web value missing from context")
+	}
+
+	var nt tag.NewTag
+	if err := web.Decode(r, &nt); err != nil {
+		return fmt.Errorf("unable to decode payload: %w", err)
+	}
+
+	t, err := h.Tag.Create(ctx, nt, v.Now)
+	if err != nil {
+		switch {
+		case errors.Is(err, tag.ErrUniqueName):
+			return v1Web.NewRequestError(err, http.StatusConflict)
+		default:
+			return fmt.Errorf("creating new tag, nt[%+v]: %w", nt, err)
+		}
+	}
+
+	return web.Respond(ctx, w, t, http.StatusCreated)
+}
+
+// Update updates a tag in the system.
+func (h Handlers) Update(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	var ut tag.UpdateTag
+	if err := web.Decode(r, &ut); err != nil {
+		return fmt.Errorf("unable to decode payload: %w", err)
+	}
+
+	id := web.Param(r, "id")
+
+	t, err := h.Tag.Update(ctx, id, ut)
+	if err != nil {
+		switch {
+		case errors.Is(err, tag.ErrInvalidID):
+			return v1Web.NewRequestError(err, http.StatusBadRequest)
+		case errors.Is(err, tag.ErrNotFound):
+			return v1Web.NewRequestError(err, http.StatusNotFound)
+		case errors.Is(err, tag.ErrUniqueName):
+			return v1Web.NewRequestError(err, http.StatusConflict)
+		default:
+			return fmt.Errorf("updating tag ID[%s], ut[%+v]: %w", id, ut, err)
+		}
+	}
+
+	return web.Respond(ctx, w, t, http.StatusOK)
+}
+
+// Delete removes a tag from the system.
+func (h Handlers) Delete(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	id := web.Param(r, "id")
+
+	if err := h.Tag.Delete(ctx, id); err != nil {
+		switch {
+		case errors.Is(err, tag.ErrInvalidID):
+			return v1Web.NewRequestError(err, http.StatusBadRequest)
+		case errors.Is(err, tag.ErrNotFound):
+			return v1Web.NewRequestError(err, http.StatusNotFound)
+		default:
+			return fmt.Errorf("deleting tag ID[%s]: %w", id, err)
+		}
+	}
+
+	return web.Respond(ctx, w, nil, http.StatusNoContent)
+}
+
+// QueryByID returns a tag by its ID.
+func (h Handlers) QueryByID(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	id := web.Param(r, "id")
+
+	t, err := h.Tag.QueryByID(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, tag.ErrInvalidID):
+			return v1Web.NewRequestError(err, http.StatusBadRequest)
+		case errors.Is(err, tag.ErrNotFound):
+			return v1Web.NewRequestError(err, http.StatusNotFound)
+		default:
+			return fmt.Errorf("ID[%s]: %w", id, err)
+		}
+	}
+
+	return web.Respond(ctx, w, t, http.StatusOK)
+}
+
+// Query returns a list of tags with paging, optionally only the curated or
+// the free-form ones.
+func (h Handlers) Query(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	page := web.Query(r, "page", defaultPage)
+	pageNumber, err := strconv.Atoi(page)
+	if err != nil {
+		return v1Web.NewRequestError(fmt.Errorf("invalid page format, page[%s]", page), http.StatusBadRequest)
+	}
+
+	size := web.Query(r, "size", defaultSize)
+	sizeNumber, err := strconv.Atoi(size)
+	if err != nil {
+		return v1Web.NewRequestError(fmt.Errorf("invalid rows format, size[%s]", size), http.StatusBadRequest)
+	}
+
+	var filter tag.QueryFilter
+	if v := r.URL.Query().Get("curated"); v != "" {
+		curated, err := strconv.ParseBool(v)
+		if err != nil {
+			return v1Web.NewRequestError(fmt.Errorf("invalid curated format, curated[%s]", v), http.StatusBadRequest)
+		}
+		filter.Curated = &curated
+	}
+
+	list, err := h.Tag.Query(ctx, filter, pageNumber, sizeNumber)
+	if err != nil {
+		return fmt.Errorf("querying tags: %w", err)
+	}
+
+	if len(list) == 0 {
+		return web.Respond(ctx, w, nil, http.StatusNoContent)
+	}
+
+	v1Web.SetPageLinks(w, r, pageNumber, sizeNumber, len(list))
+
+	return web.Respond(ctx, w, list, http.StatusOK)
+}
+
+// Cloud returns the tags in use with the number of beers they label, the
+// most used first.
+func (h Handlers) Cloud(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	limit := web.Query(r, "limit", defaultCloudLimit)
+	limitNumber, err := strconv.Atoi(limit)
+	if err != nil {
+		return v1Web.NewRequestError(fmt.Errorf("invalid limit format, limit[%s]", limit), http.StatusBadRequest)
+	}
+
+	list, err := h.Tag.QueryCloud(ctx, limitNumber)
+	if err != nil {
+		return fmt.Errorf("querying tag cloud: %w", err)
+	}
+
+	if len(list) == 0 {
+		return web.Respond(ctx, w, nil, http.StatusNoContent)
+	}
+
+	return web.Respond(ctx, w, list, http.StatusOK)
+}
@@ -4,10 +4,36 @@ package v1
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/phbpx/gobeers/app/gobeers-api/handlers/v1/beergrp"
+	"github.com/phbpx/gobeers/app/gobeers-api/handlers/v1/brewerygrp"
+	"github.com/phbpx/gobeers/app/gobeers-api/handlers/v1/checkingrp"
+	"github.com/phbpx/gobeers/app/gobeers-api/handlers/v1/imagegrp"
+	"github.com/phbpx/gobeers/app/gobeers-api/handlers/v1/moderationgrp"
+	"github.com/phbpx/gobeers/app/gobeers-api/handlers/v1/recommendationgrp"
+	"github.com/phbpx/gobeers/app/gobeers-api/handlers/v1/stylegrp"
+	"github.com/phbpx/gobeers/app/gobeers-api/handlers/v1/taggrp"
+	"github.com/phbpx/gobeers/app/gobeers-api/handlers/v1/wishlistgrp"
 	"github.com/phbpx/gobeers/business/core/beer"
+	"github.com/phbpx/gobeers/business/core/beer/stores/beercache"
 	"github.com/phbpx/gobeers/business/core/beer/stores/beerdb"
+	"github.com/phbpx/gobeers/business/core/brewery"
+	"github.com/phbpx/gobeers/business/core/brewery/stores/brewerydb"
+	"github.com/phbpx/gobeers/business/core/checkin"
+	"github.com/phbpx/gobeers/business/core/checkin/stores/checkindb"
+	"github.com/phbpx/gobeers/business/core/recommendation"
+	"github.com/phbpx/gobeers/business/core/recommendation/stores/recommendationdb"
+	"github.com/phbpx/gobeers/business/core/style"
+	"github.com/phbpx/gobeers/business/core/style/stores/styledb"
+	"github.com/phbpx/gobeers/business/core/tag"
+	"github.com/phbpx/gobeers/business/core/tag/stores/tagdb"
+	"github.com/phbpx/gobeers/business/core/wishlist"
+	"github.com/phbpx/gobeers/business/core/wishlist/stores/wishlistdb"
+	"github.com/phbpx/gobeers/business/sys/blob"
+	"github.com/phbpx/gobeers/business/sys/cursor"
+	"github.com/phbpx/gobeers/business/web/auth"
+	"github.com/phbpx/gobeers/business/web/v1/mid"
 	"github.com/phbpx/gobeers/foundation/web"
 	"github.com/uptrace/bun"
 	"go.uber.org/zap"
@@ -15,21 +41,123 @@ import (
 
 // Config contains all the mandatory systems required by handlers.
 type Config struct {
-	Log *zap.SugaredLogger
-	DB  *bun.DB
+	Log         *zap.SugaredLogger
+	DB          *bun.DB
+	CursorKey   string
+	TrendingTTL time.Duration
+	Images      blob.Store
 }
 
 // Routes binds all the version 1 routes.
 func Routes(app *web.App, cfg Config) {
 	const version = "v1"
 
+	authen := mid.Authenticate()
+	admin := mid.Authorize(auth.RoleAdmin)
+	editor := mid.Authorize(auth.RoleModerator, auth.RoleAdmin)
+
+	breweryCore := brewery.NewCore(brewerydb.NewStore(cfg.Log, cfg.DB))
+
+	// Register brewery endpoints.
+	brgh := brewerygrp.Handlers{
+		Brewery: breweryCore,
+	}
+	app.Handle(http.MethodGet, version, "/breweries", brgh.Query)
+	app.Handle(http.MethodGet, version, "/breweries/:id", brgh.QueryByID)
+	app.Handle(http.MethodPost, version, "/breweries", brgh.Create, authen)
+	app.Handle(http.MethodPut, version, "/breweries/:id", brgh.Update, authen, editor)
+	app.Handle(http.MethodPatch, version, "/breweries/:id", brgh.Update, authen, editor)
+	app.Handle(http.MethodDelete, version, "/breweries/:id", brgh.Delete, authen, editor)
+
+	styleCore := style.NewCore(styledb.NewStore(cfg.Log, cfg.DB))
+
+	// Register style endpoints.
+	sgh := stylegrp.Handlers{
+		Style: styleCore,
+	}
+	app.Handle(http.MethodGet, version, "/styles", sgh.Query)
+	app.Handle(http.MethodGet, version, "/styles/:id", sgh.QueryByID)
+
+	beerStore := beercache.NewStore(cfg.Log, beerdb.NewStore(cfg.Log, cfg.DB), cfg.TrendingTTL)
+	beerCore := beer.NewCore(breweryCore, styleCore, cfg.Images, beerStore)
+	signer := cursor.NewSigner(cfg.CursorKey)
+
 	// Register beer endpoints.
 	bgh := beergrp.Handlers{
-		Beer: beer.NewCore(beerdb.NewStore(cfg.Log, cfg.DB)),
+		Beer:   beerCore,
+		Cursor: signer,
 	}
 	app.Handle(http.MethodGet, version, "/beers", bgh.Query)
+	app.Handle(http.MethodGet, version, "/beers/search", bgh.Search)
+	app.Handle(http.MethodGet, version, "/beers/suggest", bgh.Suggest)
+	app.Handle(http.MethodGet, version, "/beers/trending", bgh.Trending)
+	app.Handle(http.MethodGet, version, "/beers/top", bgh.Top)
 	app.Handle(http.MethodGet, version, "/beers/:id", bgh.QueryByID)
+	app.Handle(http.MethodGet, version, "/beers/:id/stats", bgh.QueryStats)
+	app.Handle(http.MethodGet, version, "/beers/:id/similar", bgh.QuerySimilar)
+	app.Handle(http.MethodPost, version, "/beers/:id/images", bgh.AddImage, authen, editor)
 	app.Handle(http.MethodPost, version, "/beers", bgh.Create)
-	app.Handle(http.MethodPost, version, "/beers/:id", bgh.CreateReview)
+	app.Handle(http.MethodPut, version, "/beers/:id", bgh.Update, authen, editor)
+	app.Handle(http.MethodPatch, version, "/beers/:id", bgh.Update, authen, editor)
+	app.Handle(http.MethodDelete, version, "/beers/:id", bgh.Delete, authen, editor)
+	app.Handle(http.MethodPost, version, "/beers/:id/restore", bgh.Restore, authen, admin)
+	app.Handle(http.MethodPost, version, "/beers/:id/merge", bgh.Merge, authen, editor)
+	app.Handle(http.MethodPost, version, "/beers/:id", bgh.CreateReview, authen)
+	app.Handle(http.MethodGet, version, "/beers/:id/reviews", bgh.QueryReviews)
 	app.Handle(http.MethodPost, version, "/beers/:id/reviews", bgh.QueryReviews)
+	app.Handle(http.MethodPut, version, "/beers/:id/reviews/:rid", bgh.UpdateReview, authen)
+	app.Handle(http.MethodPatch, version, "/beers/:id/reviews/:rid", bgh.UpdateReview, authen)
+	app.Handle(http.MethodDelete, version, "/beers/:id/reviews/:rid", bgh.DeleteReview, authen)
+	app.Handle(http.MethodPost, version, "/beers/:id/reviews/:rid/restore", bgh.RestoreReview, authen, admin)
+	app.Handle(http.MethodPost, version, "/reviews/:rid/reports", bgh.ReportReview, authen)
+	app.Handle(http.MethodPost, version, "/reviews/:rid/votes", bgh.VoteReview, authen)
+
+	// Register tag endpoints.
+	tgh := taggrp.Handlers{
+		Tag: tag.NewCore(tagdb.NewStore(cfg.Log, cfg.DB)),
+	}
+	app.Handle(http.MethodGet, version, "/tags", tgh.Query)
+	app.Handle(http.MethodGet, version, "/tags/cloud", tgh.Cloud)
+	app.Handle(http.MethodGet, version, "/tags/:id", tgh.QueryByID)
+	app.Handle(http.MethodPost, version, "/tags", tgh.Create, authen, editor)
+	app.Handle(http.MethodPut, version, "/tags/:id", tgh.Update, authen, editor)
+	app.Handle(http.MethodPatch, version, "/tags/:id", tgh.Update, authen, editor)
+	app.Handle(http.MethodDelete, version, "/tags/:id", tgh.Delete, authen, editor)
+
+	// Register image endpoints.
+	igh := imagegrp.Handlers{
+		Images: cfg.Images,
+	}
+	app.Handle(http.MethodGet, version, "/images/*key", igh.QueryByKey)
+
+	// Register moderation endpoints.
+	mgh := moderationgrp.Handlers{
+		Beer: beerCore,
+	}
+	app.Handle(http.MethodGet, version, "/moderation/queue", mgh.Queue, authen, editor)
+	app.Handle(http.MethodPost, version, "/moderation/queue/:rid/approve", mgh.Approve, authen, editor)
+	app.Handle(http.MethodPost, version, "/moderation/queue/:rid/reject", mgh.Reject, authen, editor)
+
+	// Register wishlist endpoints.
+	wgh := wishlistgrp.Handlers{
+		Wishlist: wishlist.NewCore(beerCore, wishlistdb.NewStore(cfg.Log, cfg.DB)),
+	}
+	app.Handle(http.MethodGet, version, "/me/wishlist", wgh.Query, authen)
+	app.Handle(http.MethodGet, version, "/me/wishlist/:beerID", wgh.QueryByID, authen)
+	app.Handle(http.MethodPut, version, "/me/wishlist/:beerID", wgh.Add, authen)
+	app.Handle(http.MethodDelete, version, "/me/wishlist/:beerID", wgh.Remove, authen)
+
+	// Register check-in endpoints.
+	cgh := checkingrp.Handlers{
+		Checkin: checkin.NewCore(beerCore, checkindb.NewStore(cfg.Log, cfg.DB)),
+		Cursor:  signer,
+	}
+	app.Handle(http.MethodPost, version, "/checkins", cgh.Create, authen)
+	app.Handle(http.MethodGet, version, "/users/:id/checkins", cgh.QueryTimeline)
+
+	// Register recommendation endpoints.
+	rgh := recommendationgrp.Handlers{
+		Recommendation: recommendation.NewCore(beerCore, recommendationdb.NewStore(cfg.Log, cfg.DB)),
+	}
+	app.Handle(http.MethodGet, version, "/me/recommendations", rgh.Query, authen)
 }
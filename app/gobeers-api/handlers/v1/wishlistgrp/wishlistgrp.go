@@ -0,0 +1,127 @@
+// Package wishlistgrp maintains the group of handlers for the wishlist of the
+// authenticated user.
+package wishlistgrp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/phbpx/gobeers/business/core/wishlist"
+	"github.com/phbpx/gobeers/business/web/auth"
+	v1Web "github.com/phbpx/gobeers/business/web/v1"
+	"github.com/phbpx/gobeers/foundation/web"
+)
+
+const (
+	defaultPage = 1
+	defaultSize = 10
+)
+
+// Handlers manages the set of wishlist endpoints.
+type Handlers struct {
+	Wishlist wishlist.Core
+}
+
+// Add saves a beer to the wishlist.
+func (h Handlers) Add(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	v, err := web.GetValues(ctx)
+	if err != nil {
+		return web.NewShutdownError("web value missing from context")
+	}
+
+	beerID := web.Param(r, "beerID")
+	claims := auth.GetClaims(ctx)
+
+	item, err := h.Wishlist.Add(ctx, claims.PersonID, beerID, v.Now)
+	if err != nil {
+		switch {
+		case errors.Is(err, wishlist.ErrInvalidID):
+			return v1Web.NewRequestError(err, http.StatusBadRequest)
+		case errors.Is(err, wishlist.ErrBeerNotFound):
+			return v1Web.NewRequestError(err, http.StatusNotFound)
+		case errors.Is(err, wishlist.ErrForbidden):
+			return v1Web.NewRequestError(err, http.StatusForbidden)
+		default:
+			return fmt.Errorf("adding to wishlist beerID[%s]: %w", beerID, err)
+		}
+	}
+
+	return web.Respond(ctx, w, item, http.StatusOK)
+}
+
+// Remove deletes a beer from the wishlist.
+func (h Handlers) Remove(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	beerID := web.Param(r, "beerID")
+	claims := auth.GetClaims(ctx)
+
+	if err := h.Wishlist.Remove(ctx, claims.PersonID, beerID); err != nil {
+		switch {
+		case errors.Is(err, wishlist.ErrInvalidID):
+			return v1Web.NewRequestError(err, http.StatusBadRequest)
+		case errors.Is(err, wishlist.ErrNotFound):
+			return v1Web.NewRequestError(err, http.StatusNotFound)
+		case errors.Is(err, wishlist.ErrForbidden):
+			return v1Web.NewRequestError(err, http.StatusForbidden)
+		default:
+			return fmt.Errorf("removing from wishlist beerID[%s]: %w", beerID, err)
+		}
+	}
+
+	return web.Respond(ctx, w, nil, http.StatusNoContent)
+}
+
+// QueryByID returns a beer from the wishlist.
+func (h Handlers) QueryByID(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	beerID := web.Param(r, "beerID")
+	claims := auth.GetClaims(ctx)
+
+	item, err := h.Wishlist.QueryByID(ctx, claims.PersonID, beerID)
+	if err != nil {
+		switch {
+		case errors.Is(err, wishlist.ErrInvalidID):
+			return v1Web.NewRequestError(err, http.StatusBadRequest)
+		case errors.Is(err, wishlist.ErrNotFound):
+			return v1Web.NewRequestError(err, http.StatusNotFound)
+		case errors.Is(err, wishlist.ErrForbidden):
+			return v1Web.NewRequestError(err, http.StatusForbidden)
+		default:
+			return fmt.Errorf("querying wishlist beerID[%s]: %w", beerID, err)
+		}
+	}
+
+	return web.Respond(ctx, w, item, http.StatusOK)
+}
+
+// Query returns the wishlist with paging.
+func (h Handlers) Query(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	page := web.Query(r, "page", defaultPage)
+	pageNumber, err := strconv.Atoi(page)
+	if err != nil {
+		return v1Web.NewRequestError(fmt.Errorf("invalid page format, page[%s]", page), http.StatusBadRequest)
+	}
+
+	size := web.Query(r, "size", defaultSize)
+	sizeNumber, err := strconv.Atoi(size)
+	if err != nil {
+		return v1Web.NewRequestError(fmt.Errorf("invalid rows format, size[%s]", size), http.StatusBadRequest)
+	}
+
+	claims := auth.GetClaims(ctx)
+
+	items, err := h.Wishlist.Query(ctx, claims.PersonID, pageNumber, sizeNumber)
+	if err != nil {
+		switch {
+		case errors.Is(err, wishlist.ErrForbidden):
+			return v1Web.NewRequestError(err, http.StatusForbidden)
+		default:
+			return fmt.Errorf("unable to query wishlist: %w", err)
+		}
+	}
+
+	v1Web.SetPageLinks(w, r, pageNumber, sizeNumber, len(items))
+
+	return web.Respond(ctx, w, items, http.StatusOK)
+}
@@ -14,9 +14,21 @@ import (
 
 	"github.com/ardanlabs/conf/v3"
 	"github.com/phbpx/gobeers/app/gobeers-api/handlers"
+	"github.com/phbpx/gobeers/business/core/beer"
+	"github.com/phbpx/gobeers/business/core/beer/stores/beerdb"
+	"github.com/phbpx/gobeers/business/core/brewery"
+	"github.com/phbpx/gobeers/business/core/brewery/stores/brewerydb"
+	"github.com/phbpx/gobeers/business/core/recommendation"
+	"github.com/phbpx/gobeers/business/core/recommendation/stores/recommendationdb"
+	"github.com/phbpx/gobeers/business/core/style"
+	"github.com/phbpx/gobeers/business/core/style/stores/styledb"
 	"github.com/phbpx/gobeers/business/data/dbschema"
+	"github.com/phbpx/gobeers/business/sys/blob"
+	"github.com/phbpx/gobeers/business/sys/blob/local"
+	"github.com/phbpx/gobeers/business/sys/blob/s3"
 	"github.com/phbpx/gobeers/business/sys/database"
 	"github.com/phbpx/gobeers/business/web/v1/debug"
+	"github.com/phbpx/gobeers/foundation/job"
 	"github.com/phbpx/gobeers/foundation/logger"
 	"github.com/phbpx/gobeers/foundation/trace"
 	"go.uber.org/automaxprocs/maxprocs"
@@ -75,6 +87,10 @@ func run(log *zap.SugaredLogger) error {
 			ShutdownTimeout time.Duration `conf:"default:20s"`
 			APIHost         string        `conf:"default:0.0.0.0:3000"`
 			DebugHost       string        `conf:"default:0.0.0.0:4000"`
+			CursorKey       string        `conf:"required,mask"`
+		}
+		Cache struct {
+			TrendingTTL time.Duration `conf:"default:1m"`
 		}
 		DB struct {
 			User       string `conf:"default:postgres"`
@@ -83,6 +99,24 @@ func run(log *zap.SugaredLogger) error {
 			Name       string `conf:"default:postgres"`
 			DisableTLS bool   `conf:"default:true"`
 		}
+		Blob struct {
+			Kind      string `conf:"default:local,help:local or s3"`
+			LocalDir  string `conf:"default:/tmp/gobeers/blobs"`
+			LocalURL  string `conf:"default:http://localhost:3000/v1/images"`
+			Endpoint  string `conf:"default:http://localhost:9000"`
+			Region    string `conf:"default:us-east-1"`
+			Bucket    string `conf:"default:gobeers"`
+			AccessKey string `conf:"default:minioadmin"`
+			SecretKey string `conf:"default:minioadmin,mask"`
+			PublicURL string
+		}
+		Jobs struct {
+			SimilarityInterval time.Duration `conf:"default:1h"`
+			RatingInterval     time.Duration `conf:"default:10m"`
+			RatingMinVotes     int           `conf:"default:10"`
+			PurgeInterval      time.Duration `conf:"default:1h"`
+			PurgeRetention     time.Duration `conf:"default:720h"`
+		}
 		Trace struct {
 			ServiceName        string        `conf:"default:gobeers-api"`
 			ReporterURI        string        `conf:"default:http://zipkin:9411/api/v2/spans"`
@@ -148,6 +182,34 @@ func run(log *zap.SugaredLogger) error {
 		db.Close()
 	}()
 
+	// =========================================================================
+	// Blob Storage Support
+
+	log.Infow("startup", "status", "initializing blob storage support", "kind", cfg.Blob.Kind)
+
+	var images blob.Store
+	switch cfg.Blob.Kind {
+	case "local":
+		images = local.NewStore(cfg.Blob.LocalDir, cfg.Blob.LocalURL)
+
+	case "s3":
+		store := s3.NewStore(s3.Config{
+			Endpoint:  cfg.Blob.Endpoint,
+			Region:    cfg.Blob.Region,
+			Bucket:    cfg.Blob.Bucket,
+			AccessKey: cfg.Blob.AccessKey,
+			SecretKey: cfg.Blob.SecretKey,
+			PublicURL: cfg.Blob.PublicURL,
+		})
+		if err := store.CreateBucket(context.Background()); err != nil {
+			return fmt.Errorf("creating blob bucket: %w", err)
+		}
+		images = store
+
+	default:
+		return fmt.Errorf("unknown blob storage kind %q", cfg.Blob.Kind)
+	}
+
 	// =========================================================================
 	// Start Tracing Support
 
@@ -189,6 +251,50 @@ func run(log *zap.SugaredLogger) error {
 		}
 	}()
 
+	// =========================================================================
+	// Start Background Jobs
+
+	log.Infow("startup", "status", "starting background jobs")
+
+	// The jobs stop when run returns, once the api is shut down.
+	jobCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+
+	beerCore := beer.NewCore(
+		brewery.NewCore(brewerydb.NewStore(log, db)),
+		style.NewCore(styledb.NewStore(log, db)),
+		images,
+		beerdb.NewStore(log, db),
+	)
+	recCore := recommendation.NewCore(beerCore, recommendationdb.NewStore(log, db))
+
+	go job.Every(jobCtx, cfg.Jobs.SimilarityInterval,
+		func(ctx context.Context) error {
+			return recCore.RefreshSimilarities(ctx, time.Now())
+		},
+		func(err error) {
+			log.Errorw("job", "status", "refreshing beer similarities", "ERROR", err)
+		},
+	)
+
+	go job.Every(jobCtx, cfg.Jobs.RatingInterval,
+		func(ctx context.Context) error {
+			return beerCore.RefreshRatings(ctx, cfg.Jobs.RatingMinVotes, time.Now())
+		},
+		func(err error) {
+			log.Errorw("job", "status", "refreshing beer ratings", "ERROR", err)
+		},
+	)
+
+	go job.Every(jobCtx, cfg.Jobs.PurgeInterval,
+		func(ctx context.Context) error {
+			return beerCore.Purge(ctx, time.Now().Add(-cfg.Jobs.PurgeRetention))
+		},
+		func(err error) {
+			log.Errorw("job", "status", "purging deleted beers", "ERROR", err)
+		},
+	)
+
 	// =========================================================================
 	// Start API Service
 
@@ -201,10 +307,13 @@ func run(log *zap.SugaredLogger) error {
 
 	// Construct the mux for the API calls.
 	apiMux := handlers.APIMux(handlers.APIMuxConfig{
-		Shutdown: shutdown,
-		Log:      log,
-		DB:       db,
-		Tracer:   tracer,
+		Shutdown:    shutdown,
+		Log:         log,
+		DB:          db,
+		Tracer:      tracer,
+		CursorKey:   cfg.Web.CursorKey,
+		TrendingTTL: cfg.Cache.TrendingTTL,
+		Images:      images,
 	})
 
 	// Construct a server to service the requests against the mux.
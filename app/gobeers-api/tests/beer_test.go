@@ -1,7 +1,11 @@
 package tests
 
 import (
+	"crypto/rand"
+	"crypto/rsa"
 	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -9,11 +13,14 @@ import (
 	"testing"
 
 	v1Web "github.com/ardanlabs/service/business/web/v1"
+	"github.com/golang-jwt/jwt/v4"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/uuid"
 	"github.com/phbpx/gobeers/app/gobeers-api/handlers"
 	"github.com/phbpx/gobeers/business/data/dbtest"
 	"github.com/phbpx/gobeers/business/sys/validate"
+	"github.com/phbpx/gobeers/business/web/auth"
 )
 
 // BeerTests holds methods for each beer subtest. This type allows
@@ -21,6 +28,7 @@ import (
 // when subtests are registered.
 type BeerTests struct {
 	app http.Handler
+	key *rsa.PrivateKey
 }
 
 func TestBeers(t *testing.T) {
@@ -29,18 +37,28 @@ func TestBeers(t *testing.T) {
 	test := dbtest.NewIntegration(t, c, "inttestprods")
 	t.Cleanup(test.Teardown)
 
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generating token key: %s", err)
+	}
+
 	shutdown := make(chan os.Signal, 1)
 	tests := BeerTests{
 		app: handlers.APIMux(handlers.APIMuxConfig{
-			Shutdown: shutdown,
-			Log:      test.Log,
-			DB:       test.DB,
+			Shutdown:  shutdown,
+			Log:       test.Log,
+			DB:        test.DB,
+			CursorKey: "test-cursor-key",
 		}),
+		key: key,
 	}
 
 	t.Run("postBeers400", tests.postBeers400)
 	t.Run("getBeers400", tests.getBeers400)
 	t.Run("getBeers404", tests.getBeers404)
+	t.Run("getBeersCursor400", tests.getBeersCursor400)
+	t.Run("mergeBeers", tests.mergeBeers)
+	t.Run("restoreBeer", tests.restoreBeer)
 }
 
 // postBeers400 validates a beer can't be created with the endpoint
@@ -69,7 +87,7 @@ func (bt *BeerTests) postBeers400(t *testing.T) {
 
 			fields := validate.FieldErrors{
 				{Field: "name", Error: "name is a required field"},
-				{Field: "brewery", Error: "brewery is a required field"},
+				{Field: "brewery_id", Error: "brewery_id is a required field"},
 				{Field: "style", Error: "style is a required field"},
 				{Field: "abv", Error: "abv is a required field"},
 				{Field: "short_desc", Error: "short_desc is a required field"},
@@ -140,3 +158,198 @@ func (bt *BeerTests) getBeers404(t *testing.T) {
 		}
 	}
 }
+
+// getBeersCursor400 validates paging by cursor rejects a malformed cursor and
+// a page size out of bounds.
+func (bt *BeerTests) getBeersCursor400(t *testing.T) {
+	t.Log("Given the need to validate paging beers by cursor.")
+	{
+		for _, query := range []string{"cursor=garbage", "cursor=&size=-1", "cursor=&size=1000"} {
+			t.Logf("\t When using the query %s.", query)
+			{
+				w := bt.request(http.MethodGet, "/v1/beers?"+query, "", "")
+
+				if w.Code != http.StatusBadRequest {
+					t.Fatalf("\t [ERROR] Should receive a status code of 400 for the response : %v", w.Code)
+				}
+				t.Log("\t [SUCCESS] Should receive a status code of 400 for the response.")
+			}
+		}
+	}
+}
+
+// mergeBeers validates only moderators and admins can merge beers and the
+// merged beer redirects to the canonical beer.
+func (bt *BeerTests) mergeBeers(t *testing.T) {
+	breweryID := bt.createBrewery(t, "Merge Brewery")
+	canonicalID := bt.createBeer(t, breweryID, "Merge IPA")
+	duplicateID := bt.createBeer(t, breweryID, "Merged Pale")
+
+	path := "/v1/beers/" + canonicalID + "/merge"
+	body := fmt.Sprintf(`{"duplicate_id":%q}`, duplicateID)
+
+	t.Log("Given the need to merge a duplicate beer.")
+	{
+		t.Log("\t When merging without the moderator or admin role.")
+		{
+			if w := bt.request(http.MethodPost, path, body, ""); w.Code != http.StatusUnauthorized {
+				t.Fatalf("\t [ERROR] Should receive a status code of 401 without a token : %v", w.Code)
+			}
+			t.Log("\t [SUCCESS] Should receive a status code of 401 without a token.")
+
+			if w := bt.request(http.MethodPost, path, body, bt.token(t)); w.Code != http.StatusForbidden {
+				t.Fatalf("\t [ERROR] Should receive a status code of 403 without the role : %v", w.Code)
+			}
+			t.Log("\t [SUCCESS] Should receive a status code of 403 without the role.")
+		}
+
+		t.Log("\t When merging as a moderator.")
+		{
+			moderator := bt.token(t, auth.RoleModerator)
+
+			if w := bt.request(http.MethodPost, path, body, moderator); w.Code != http.StatusOK {
+				t.Fatalf("\t [ERROR] Should receive a status code of 200 for the response : %v", w.Code)
+			}
+			t.Log("\t [SUCCESS] Should receive a status code of 200 for the response.")
+
+			location := "/v1/beers/" + canonicalID
+
+			w := bt.request(http.MethodGet, "/v1/beers/"+duplicateID, "", "")
+			if w.Code != http.StatusMovedPermanently || w.Header().Get("Location") != location {
+				t.Fatalf("\t [ERROR] Should redirect a GET of the merged beer with a 301 : %v %s", w.Code, w.Header().Get("Location"))
+			}
+			t.Log("\t [SUCCESS] Should redirect a GET of the merged beer with a 301.")
+
+			w = bt.request(http.MethodPut, "/v1/beers/"+duplicateID, `{"abv":5}`, moderator)
+			if w.Code != http.StatusPermanentRedirect || w.Header().Get("Location") != location {
+				t.Fatalf("\t [ERROR] Should redirect a PUT of the merged beer with a 308 : %v %s", w.Code, w.Header().Get("Location"))
+			}
+			t.Log("\t [SUCCESS] Should redirect a PUT of the merged beer with a 308.")
+		}
+
+		t.Log("\t When merging as an admin.")
+		{
+			duplicateID := bt.createBeer(t, breweryID, "Merged Stout")
+			body := fmt.Sprintf(`{"duplicate_id":%q}`, duplicateID)
+
+			if w := bt.request(http.MethodPost, path, body, bt.token(t, auth.RoleAdmin)); w.Code != http.StatusOK {
+				t.Fatalf("\t [ERROR] Should receive a status code of 200 for the response : %v", w.Code)
+			}
+			t.Log("\t [SUCCESS] Should receive a status code of 200 for the response.")
+		}
+	}
+}
+
+// restoreBeer validates only admins can restore a deleted beer.
+func (bt *BeerTests) restoreBeer(t *testing.T) {
+	breweryID := bt.createBrewery(t, "Restore Brewery")
+	beerID := bt.createBeer(t, breweryID, "Restore Lager")
+
+	moderator := bt.token(t, auth.RoleModerator)
+	path := "/v1/beers/" + beerID + "/restore"
+
+	t.Log("Given the need to restore a deleted beer.")
+	{
+		t.Log("\t When deleting the beer.")
+		{
+			if w := bt.request(http.MethodDelete, "/v1/beers/"+beerID, "", ""); w.Code != http.StatusUnauthorized {
+				t.Fatalf("\t [ERROR] Should receive a status code of 401 without a token : %v", w.Code)
+			}
+			t.Log("\t [SUCCESS] Should receive a status code of 401 without a token.")
+
+			if w := bt.request(http.MethodDelete, "/v1/beers/"+beerID, "", moderator); w.Code != http.StatusNoContent {
+				t.Fatalf("\t [ERROR] Should receive a status code of 204 for the response : %v", w.Code)
+			}
+			t.Log("\t [SUCCESS] Should receive a status code of 204 for the response.")
+		}
+
+		t.Log("\t When restoring the beer.")
+		{
+			if w := bt.request(http.MethodPost, path, "", moderator); w.Code != http.StatusForbidden {
+				t.Fatalf("\t [ERROR] Should receive a status code of 403 without the admin role : %v", w.Code)
+			}
+			t.Log("\t [SUCCESS] Should receive a status code of 403 without the admin role.")
+
+			if w := bt.request(http.MethodPost, path, "", bt.token(t, auth.RoleAdmin)); w.Code != http.StatusOK {
+				t.Fatalf("\t [ERROR] Should receive a status code of 200 for the response : %v", w.Code)
+			}
+			t.Log("\t [SUCCESS] Should receive a status code of 200 for the response.")
+
+			if w := bt.request(http.MethodGet, "/v1/beers/"+beerID, "", ""); w.Code != http.StatusOK {
+				t.Fatalf("\t [ERROR] Should be able to get the restored beer : %v", w.Code)
+			}
+			t.Log("\t [SUCCESS] Should be able to get the restored beer.")
+		}
+	}
+}
+
+// request sends a request to the app, authenticated with the token if any.
+func (bt *BeerTests) request(method string, path string, body string, token string) *httptest.ResponseRecorder {
+	var rd io.Reader
+	if body != "" {
+		rd = strings.NewReader(body)
+	}
+
+	r := httptest.NewRequest(method, path, rd)
+	if token != "" {
+		r.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	w := httptest.NewRecorder()
+	bt.app.ServeHTTP(w, r)
+
+	return w
+}
+
+// token builds a token for a new user with the provided roles.
+func (bt *BeerTests) token(t *testing.T, roles ...string) string {
+	claims := auth.Claims{
+		BusinessID: uuid.NewString(),
+		PersonID:   uuid.NewString(),
+		AppID:      "gobeers-tests",
+		Roles:      roles,
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = "gobeers-tests"
+
+	signed, err := token.SignedString(bt.key)
+	if err != nil {
+		t.Fatalf("\t [ERROR] Should be able to sign a token : %s", err)
+	}
+
+	return signed
+}
+
+// createBrewery adds a brewery and returns its id.
+func (bt *BeerTests) createBrewery(t *testing.T, name string) string {
+	w := bt.request(http.MethodPost, "/v1/breweries", fmt.Sprintf(`{"name":%q}`, name), bt.token(t))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("\t [ERROR] Should be able to add a brewery : %v %s", w.Code, w.Body)
+	}
+
+	return decodeID(t, w)
+}
+
+// createBeer adds a beer of the brewery and returns its id.
+func (bt *BeerTests) createBeer(t *testing.T, breweryID string, name string) string {
+	body := fmt.Sprintf(`{"name":%q,"brewery_id":%q,"style":"American IPA","abv":6.5,"short_desc":"Test Short Description"}`, name, breweryID)
+
+	w := bt.request(http.MethodPost, "/v1/beers", body, "")
+	if w.Code != http.StatusCreated {
+		t.Fatalf("\t [ERROR] Should be able to add a beer : %v %s", w.Code, w.Body)
+	}
+
+	return decodeID(t, w)
+}
+
+func decodeID(t *testing.T, w *httptest.ResponseRecorder) string {
+	var got struct {
+		ID string `json:"id"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("\t [ERROR] Should be able to unmarshal the response : %v", err)
+	}
+
+	return got.ID
+}
@@ -7,37 +7,118 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/phbpx/gobeers/business/core/brewery"
+	"github.com/phbpx/gobeers/business/core/style"
+	"github.com/phbpx/gobeers/business/core/tag"
+	"github.com/phbpx/gobeers/business/sys/blob"
+	"github.com/phbpx/gobeers/business/sys/cursor"
 	"github.com/phbpx/gobeers/business/sys/database"
+	"github.com/phbpx/gobeers/business/sys/order"
 	"github.com/phbpx/gobeers/business/sys/validate"
 )
 
 // Set of error variables for CRUD operations.
 var (
-	ErrNotFound  = errors.New("beer not found")
-	ErrInvalidID = errors.New("ID is not in its proper form")
+	ErrNotFound        = errors.New("beer not found")
+	ErrReviewNotFound  = errors.New("review not found")
+	ErrInvalidID       = errors.New("ID is not in its proper form")
+	ErrForbidden       = errors.New("attempted action is not allowed")
+	ErrDuplicateReview = errors.New("beer already reviewed by user")
+	ErrDuplicateReport = errors.New("review already reported by user")
 )
 
-// Storer .
+// Unique constraints allowing a single review per user of every beer, and a
+// single report per user of every review.
+const (
+	uniqueReviewAuthor = "reviews_beer_id_user_id_key"
+	uniqueReportAuthor = "review_reports_review_id_user_id_key"
+)
+
+// Limits for typeahead suggestions, keeping them cheap enough to run on every
+// keystroke.
+const (
+	maxSuggestPrefix = 100
+	maxSuggestLimit  = 20
+)
+
+// maxSimilarLimit is the maximum number of similar beers returned at once.
+const maxSimilarLimit = 50
+
+// Bounds for the trending beers.
+const (
+	MinTrendingWindow = time.Hour
+	MaxTrendingWindow = 90 * 24 * time.Hour
+	maxTrendingLimit  = 50
+)
+
+// Storer interface declares the behavior this package needs to persist and
+// retrieve data.
 type Storer interface {
+	WithinTran(ctx context.Context, fn func(Storer) error) error
 	AddBeer(ctx context.Context, beer Beer) error
-	QueryBeers(ctx context.Context, page int, size int) ([]Beer, error)
+	QueryBeers(ctx context.Context, filter QueryFilter, orderBy []order.By, page int, size int) ([]Beer, error)
+	QueryBeersByCursor(ctx context.Context, filter QueryFilter, cur cursor.Cursor, limit int) ([]Beer, error)
 	QueryBeerByID(ctx context.Context, beerID string) (Beer, error)
+	QueryBeerByIDWithDeleted(ctx context.Context, beerID string) (Beer, error)
+	QueryBeerByNormalizedName(ctx context.Context, breweryID string, normalizedName string) (Beer, error)
+	QueryBeersByIDs(ctx context.Context, beerIDs []string) ([]Beer, error)
+	SearchBeers(ctx context.Context, text string, page int, size int) ([]SearchResult, error)
+	SuggestBeers(ctx context.Context, prefix string, limit int) ([]Suggestion, error)
+	QuerySimilarBeers(ctx context.Context, beerID string, weights SimilarityWeights, limit int) ([]SimilarBeer, error)
+	QueryTrendingBeers(ctx context.Context, window time.Duration, limit int, now time.Time) ([]TrendingBeer, error)
+	RefreshRatings(ctx context.Context, minVotes int, now time.Time) error
+	QueryTopBeers(ctx context.Context, filter QueryFilter, page int, size int) ([]TopBeer, error)
+	AddImage(ctx context.Context, image Image) error
+	QueryImages(ctx context.Context, beerID string) ([]Image, error)
+	SetBeerTags(ctx context.Context, beerID string, names []string, now time.Time) error
+	UpdateBeer(ctx context.Context, beer Beer) error
+	DeleteBeer(ctx context.Context, beerID string) error
+	RestoreBeer(ctx context.Context, beerID string) error
+	PurgeBeers(ctx context.Context, before time.Time) ([]string, error)
+	MergeBeers(ctx context.Context, duplicateID string, canonicalID string, now time.Time) error
+	QueryRedirect(ctx context.Context, beerID string) (string, error)
 	AddReview(ctx context.Context, review Review) error
-	QueryBeerReviews(ctx context.Context, beerID string, page int, size int) ([]Review, error)
+	UpsertReview(ctx context.Context, review Review) (Review, error)
+	UpdateReview(ctx context.Context, review Review) error
+	DeleteReview(ctx context.Context, reviewID string) error
+	RestoreReview(ctx context.Context, reviewID string) error
+	PurgeReviews(ctx context.Context, before time.Time) error
+	QueryReviewByID(ctx context.Context, reviewID string) (Review, error)
+	AddReport(ctx context.Context, report Report) error
+	IncrementReportCount(ctx context.Context, reviewID string) (int, error)
+	ResetReportCount(ctx context.Context, reviewID string) error
+	ModerateReview(ctx context.Context, review Review) error
+	QueryModerationQueue(ctx context.Context, page int, size int) ([]Review, error)
+	UpsertVote(ctx context.Context, vote Vote) error
+	UpdateReviewVotes(ctx context.Context, reviewID string) error
+	SaveScoresheet(ctx context.Context, reviewID string, sheet Scoresheet) error
+	DeleteScoresheet(ctx context.Context, reviewID string) error
+	QueryScoresheetStats(ctx context.Context, beerID string) (ScoresheetStats, error)
+	UpdateBeerStats(ctx context.Context, beerID string) error
+	QueryBeerReviews(ctx context.Context, beerID string, orderBy []order.By, page int, size int) ([]Review, error)
+	QueryBeerReviewsByCursor(ctx context.Context, beerID string, cur cursor.Cursor, limit int) ([]Review, error)
 }
 
 // Core manages the set of APIs for beer access.
 type Core struct {
-	store Storer
+	brewery brewery.Core
+	style   style.Core
+	images  blob.Store
+	store   Storer
 }
 
 // NewCore constructs a core for product api access.
-func NewCore(store Storer) Core {
+func NewCore(breweryCore brewery.Core, styleCore style.Core, images blob.Store, store Storer) Core {
 	return Core{
-		store: store,
+		brewery: breweryCore,
+		style:   styleCore,
+		images:  images,
+		store:   store,
 	}
 }
 
@@ -45,29 +126,142 @@ func NewCore(store Storer) Core {
 // Beer Support
 
 // Create adds an beer to the database. Its return the created Beer
-// with fields populated.
-func (c Core) Create(ctx context.Context, nb NewBeer) (Beer, error) {
+// with fields populated. A beer with the same normalized name as another beer
+// of the brewery is rejected with a DuplicateError, unless force is set.
+func (c Core) Create(ctx context.Context, nb NewBeer, force bool) (Beer, error) {
 	if err := validate.Check(nb); err != nil {
 		return Beer{}, fmt.Errorf("validating data: %w", err)
 	}
 
+	brw, err := c.queryBrewery(ctx, nb.BreweryID)
+	if err != nil {
+		return Beer{}, err
+	}
+
+	st, err := c.queryStyle(ctx, nb.Style)
+	if err != nil {
+		return Beer{}, err
+	}
+
 	beer := Beer{
-		ID:        uuid.New().String(),
-		Name:      nb.Name,
-		Brewery:   nb.Brewery,
-		Style:     nb.Style,
-		ABV:       nb.ABV,
-		ShortDesc: nb.ShortDesc,
-		CreatedAt: time.Now(),
+		ID:             uuid.New().String(),
+		Name:           nb.Name,
+		BreweryID:      brw.ID,
+		Brewery:        brw.Name,
+		Style:          st.Name,
+		ABV:            nb.ABV,
+		ShortDesc:      nb.ShortDesc,
+		ImageURLs:      []string{},
+		Tags:           normalizeTags(nb.Tags),
+		NormalizedName: NormalizeName(nb.Name),
+		Forced:         force,
+		CreatedAt:      time.Now(),
+	}
+
+	tran := func(s Storer) error {
+		if err := s.AddBeer(ctx, beer); err != nil {
+			return fmt.Errorf("addBeer: %w", err)
+		}
+		if err := s.SetBeerTags(ctx, beer.ID, beer.Tags, beer.CreatedAt); err != nil {
+			return fmt.Errorf("setBeerTags: %w", err)
+		}
+		return nil
+	}
+
+	if err := c.store.WithinTran(ctx, tran); err != nil {
+		return Beer{}, c.checkDuplicate(ctx, beer, fmt.Errorf("tran: %w", err))
+	}
+
+	return beer, nil
+}
+
+// Update replaces the fields of a beer that are set in the UpdateBeer
+// value. Its return the updated Beer.
+func (c Core) Update(ctx context.Context, beerID string, ub UpdateBeer) (Beer, error) {
+	if err := validate.CheckID(beerID); err != nil {
+		return Beer{}, ErrInvalidID
+	}
+
+	if err := validate.Check(ub); err != nil {
+		return Beer{}, fmt.Errorf("validating data: %w", err)
+	}
+
+	beer, err := c.store.QueryBeerByID(ctx, beerID)
+	if err != nil {
+		if database.IsNoRowError(err) {
+			return Beer{}, c.checkMerged(ctx, beerID)
+		}
+		return Beer{}, fmt.Errorf("updating beer beerID[%s]: %w", beerID, err)
+	}
+
+	if ub.Name != nil {
+		beer.Name = *ub.Name
+	}
+	if ub.BreweryID != nil {
+		brw, err := c.queryBrewery(ctx, *ub.BreweryID)
+		if err != nil {
+			return Beer{}, err
+		}
+		beer.BreweryID = brw.ID
+		beer.Brewery = brw.Name
+	}
+	if ub.Style != nil {
+		st, err := c.queryStyle(ctx, *ub.Style)
+		if err != nil {
+			return Beer{}, err
+		}
+		beer.Style = st.Name
+	}
+	if ub.ABV != nil {
+		beer.ABV = *ub.ABV
+	}
+	if ub.ShortDesc != nil {
+		beer.ShortDesc = *ub.ShortDesc
+	}
+	if ub.Tags != nil {
+		beer.Tags = normalizeTags(*ub.Tags)
+	}
+
+	beer.NormalizedName = NormalizeName(beer.Name)
+
+	tran := func(s Storer) error {
+		if err := s.UpdateBeer(ctx, beer); err != nil {
+			return fmt.Errorf("updateBeer: %w", err)
+		}
+		if ub.Tags == nil {
+			return nil
+		}
+		if err := s.SetBeerTags(ctx, beer.ID, beer.Tags, time.Now()); err != nil {
+			return fmt.Errorf("setBeerTags: %w", err)
+		}
+		return nil
 	}
 
-	if err := c.store.AddBeer(ctx, beer); err != nil {
-		return Beer{}, fmt.Errorf("addBeer: %w", err)
+	if err := c.store.WithinTran(ctx, tran); err != nil {
+		return Beer{}, c.checkDuplicate(ctx, beer, fmt.Errorf("tran: %w", err))
 	}
 
 	return beer, nil
 }
 
+// Delete soft deletes the specified beer. Its reviews are left untouched but
+// hidden while the beer is deleted. It can be restored until the purge job
+// removes it for good.
+func (c Core) Delete(ctx context.Context, beerID string) error {
+	if err := validate.CheckID(beerID); err != nil {
+		return ErrInvalidID
+	}
+
+	if err := c.store.DeleteBeer(ctx, beerID); err != nil {
+		if database.IsNoRowError(err) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("deleteBeer: %w", err)
+	}
+
+	return nil
+}
+
 // QueryByID gets the specified beer from the database.
 func (c Core) QueryByID(ctx context.Context, id string) (Beer, error) {
 	if err := validate.CheckID(id); err != nil {
@@ -77,7 +271,7 @@ func (c Core) QueryByID(ctx context.Context, id string) (Beer, error) {
 	beer, err := c.store.QueryBeerByID(ctx, id)
 	if err != nil {
 		if database.IsNoRowError(err) {
-			return Beer{}, ErrNotFound
+			return Beer{}, c.checkMerged(ctx, id)
 		}
 		return Beer{}, fmt.Errorf("queryBeerByID: %w", err)
 	}
@@ -85,9 +279,53 @@ func (c Core) QueryByID(ctx context.Context, id string) (Beer, error) {
 	return beer, nil
 }
 
-// Query gets all beers from the database.
-func (c Core) Query(ctx context.Context, page, pageSize int) ([]Beer, error) {
-	beers, err := c.store.QueryBeers(ctx, page, pageSize)
+// QueryByIDs gets the specified beers from the database, in the order of
+// the provided ids. Unknown ids are skipped.
+func (c Core) QueryByIDs(ctx context.Context, beerIDs []string) ([]Beer, error) {
+	for _, id := range beerIDs {
+		if err := validate.CheckID(id); err != nil {
+			return nil, ErrInvalidID
+		}
+	}
+
+	if len(beerIDs) == 0 {
+		return nil, nil
+	}
+
+	found, err := c.store.QueryBeersByIDs(ctx, beerIDs)
+	if err != nil {
+		return nil, fmt.Errorf("queryBeersByIDs: %w", err)
+	}
+
+	byID := make(map[string]Beer, len(found))
+	for _, b := range found {
+		byID[b.ID] = b
+	}
+
+	beers := make([]Beer, 0, len(found))
+	for _, id := range beerIDs {
+		if b, exists := byID[id]; exists {
+			beers = append(beers, b)
+		}
+	}
+
+	return beers, nil
+}
+
+// Query gets all beers from the database that match the filter, in the
+// requested order.
+func (c Core) Query(ctx context.Context, filter QueryFilter, orderBy []order.By, page, pageSize int) ([]Beer, error) {
+	if err := order.Check(orderBy, beerOrderByFields); err != nil {
+		return nil, err
+	}
+
+	filter.Tags = normalizeTags(filter.Tags)
+
+	if err := checkFilter(filter); err != nil {
+		return nil, err
+	}
+
+	beers, err := c.store.QueryBeers(ctx, filter, orderBy, page, pageSize)
 	if err != nil {
 		return nil, fmt.Errorf("queryBeers: %w", err)
 	}
@@ -95,16 +333,163 @@ func (c Core) Query(ctx context.Context, page, pageSize int) ([]Beer, error) {
 	return beers, nil
 }
 
+// QueryByCursor gets a page of beers that match the filter, positioned by the
+// cursor. Along with the beers it returns the cursors to the pages around it.
+func (c Core) QueryByCursor(ctx context.Context, filter QueryFilter, cur cursor.Cursor, size int) ([]Beer, cursor.Page, error) {
+	if err := cursor.CheckSize(size); err != nil {
+		return nil, cursor.Page{}, err
+	}
+
+	if err := order.Check(cur.OrderBy, beerOrderByFields); err != nil {
+		return nil, cursor.Page{}, err
+	}
+
+	filter.Tags = normalizeTags(filter.Tags)
+
+	if err := checkFilter(filter); err != nil {
+		return nil, cursor.Page{}, err
+	}
+
+	// Fetch one extra beer to know if there is a page past this one.
+	beers, err := c.store.QueryBeersByCursor(ctx, filter, cur, size+1)
+	if err != nil {
+		return nil, cursor.Page{}, fmt.Errorf("queryBeersByCursor: %w", err)
+	}
+
+	beers, page := cursor.Paginate(beers, cur, size, beerCursorValues)
+
+	return beers, page, nil
+}
+
+// Search runs a full text search over the beers and returns them ranked by
+// relevance.
+func (c Core) Search(ctx context.Context, text string, page int, size int) ([]SearchResult, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, validate.FieldErrors{{Field: "q", Error: "q is a required field"}}
+	}
+
+	results, err := c.store.SearchBeers(ctx, text, page, size)
+	if err != nil {
+		return nil, fmt.Errorf("searchBeers: %w", err)
+	}
+
+	return results, nil
+}
+
+// Suggest returns the top beer names and breweries matching the prefix, for
+// typeahead search boxes.
+func (c Core) Suggest(ctx context.Context, prefix string, limit int) ([]Suggestion, error) {
+	prefix = strings.TrimSpace(prefix)
+
+	switch {
+	case prefix == "":
+		return nil, validate.FieldErrors{{Field: "prefix", Error: "prefix is a required field"}}
+	case len(prefix) > maxSuggestPrefix:
+		return nil, validate.FieldErrors{{Field: "prefix", Error: fmt.Sprintf("prefix must be a maximum of %d characters in length", maxSuggestPrefix)}}
+	case limit < 1 || limit > maxSuggestLimit:
+		return nil, validate.FieldErrors{{Field: "limit", Error: fmt.Sprintf("limit must be between 1 and %d", maxSuggestLimit)}}
+	}
+
+	suggestions, err := c.store.SuggestBeers(ctx, prefix, limit)
+	if err != nil {
+		return nil, fmt.Errorf("suggestBeers: %w", err)
+	}
+
+	return suggestions, nil
+}
+
+// QuerySimilar returns the beers closest to the specified beer, the most
+// similar first. Beers are compared on their style, description keywords,
+// ABV and brewery, weighted by DefaultSimilarityWeights.
+func (c Core) QuerySimilar(ctx context.Context, beerID string, limit int) ([]SimilarBeer, error) {
+	if limit < 1 || limit > maxSimilarLimit {
+		return nil, validate.FieldErrors{{Field: "limit", Error: fmt.Sprintf("limit must be between 1 and %d", maxSimilarLimit)}}
+	}
+
+	if _, err := c.QueryByID(ctx, beerID); err != nil {
+		return nil, err
+	}
+
+	similar, err := c.store.QuerySimilarBeers(ctx, beerID, DefaultSimilarityWeights, limit)
+	if err != nil {
+		return nil, fmt.Errorf("querySimilarBeers: %w", err)
+	}
+
+	return similar, nil
+}
+
+// QueryTrending returns the beers trending over the window ending now, the
+// hottest first. A beer trends when it gets more reviews within the window
+// than it used to get before it, with recent reviews counting the most.
+func (c Core) QueryTrending(ctx context.Context, window time.Duration, limit int, now time.Time) ([]TrendingBeer, error) {
+	switch {
+	case window < MinTrendingWindow || window > MaxTrendingWindow:
+		return nil, validate.FieldErrors{{Field: "window", Error: fmt.Sprintf("window must be between %s and %s", MinTrendingWindow, MaxTrendingWindow)}}
+	case limit < 1 || limit > maxTrendingLimit:
+		return nil, validate.FieldErrors{{Field: "limit", Error: fmt.Sprintf("limit must be between 1 and %d", maxTrendingLimit)}}
+	}
+
+	trending, err := c.store.QueryTrendingBeers(ctx, window, limit, now)
+	if err != nil {
+		return nil, fmt.Errorf("queryTrendingBeers: %w", err)
+	}
+
+	return trending, nil
+}
+
+// RefreshRatings recomputes the Bayesian rating of every reviewed beer:
+//
+//	rating = (v * R + m * C) / (v + m)
+//
+// where v is the number of reviews of the beer, R its average score, C the
+// mean score of all the reviews and m the minVotes prior, the number of
+// reviews from which a beer average starts to outweigh the mean. It's meant
+// to run periodically.
+func (c Core) RefreshRatings(ctx context.Context, minVotes int, now time.Time) error {
+	if minVotes < 0 {
+		return fmt.Errorf("minVotes must be positive, minVotes[%d]", minVotes)
+	}
+
+	if err := c.store.RefreshRatings(ctx, minVotes, now); err != nil {
+		return fmt.Errorf("refreshRatings: %w", err)
+	}
+
+	return nil
+}
+
+// QueryTop gets the best rated beers that match the filter, by their
+// Bayesian rating as of the last RefreshRatings.
+func (c Core) QueryTop(ctx context.Context, filter QueryFilter, page int, size int) ([]TopBeer, error) {
+	filter.Tags = normalizeTags(filter.Tags)
+
+	if err := checkFilter(filter); err != nil {
+		return nil, err
+	}
+
+	top, err := c.store.QueryTopBeers(ctx, filter, page, size)
+	if err != nil {
+		return nil, fmt.Errorf("queryTopBeers: %w", err)
+	}
+
+	return top, nil
+}
+
 // =========================================================================
 // Beer Review Support
 
-// CreateReview adds a review to the database. Its return the created Review
-// with fields populated.
-func (c Core) CreateReview(ctx context.Context, beerID string, nr NewReview, now time.Time) (Review, error) {
+// CreateReview adds a review written by the user to the database. Its return
+// the created Review with fields populated. A user can only review a beer
+// once: when upsert is set an existing review is replaced, otherwise
+// ErrDuplicateReview is returned.
+func (c Core) CreateReview(ctx context.Context, userID string, beerID string, nr NewReview, upsert bool, now time.Time) (Review, error) {
 	if err := validate.CheckID(beerID); err != nil {
 		return Review{}, ErrInvalidID
 	}
 
+	if err := validate.CheckID(userID); err != nil {
+		return Review{}, ErrForbidden
+	}
+
 	if err := validate.Check(nr); err != nil {
 		return Review{}, fmt.Errorf("validating data: %w", err)
 	}
@@ -117,32 +502,307 @@ func (c Core) CreateReview(ctx context.Context, beerID string, nr NewReview, now
 		return Review{}, fmt.Errorf("reviewing beer berrID[%s]: %w", beerID, err)
 	}
 
+	score := nr.Score
+	if nr.Scoresheet != nil {
+		score = nr.Scoresheet.Score()
+	}
+
 	review := Review{
 		ID:        uuid.New().String(),
-		UserID:    nr.UserID,
+		UserID:    userID,
 		BeerID:    beer.ID,
-		Score:     nr.Score,
+		Score:     score,
 		Comment:   nr.Comment,
+		Status:    ReviewPublished,
 		CreatedAt: now,
 	}
 
-	if err := c.store.AddReview(ctx, review); err != nil {
-		return Review{}, fmt.Errorf("addReview: %w", err)
+	tran := func(s Storer) error {
+		if upsert {
+			saved, err := s.UpsertReview(ctx, review)
+			if err != nil {
+				return fmt.Errorf("upsertReview: %w", err)
+			}
+			review = saved
+		} else {
+			if err := s.AddReview(ctx, review); err != nil {
+				return fmt.Errorf("addReview: %w", err)
+			}
+		}
+		if nr.Scoresheet != nil || upsert {
+			if err := saveScoresheet(ctx, s, review.ID, nr.Scoresheet); err != nil {
+				return err
+			}
+		}
+		review.Scoresheet = nr.Scoresheet
+		if err := s.UpdateBeerStats(ctx, beer.ID); err != nil {
+			return fmt.Errorf("updateBeerStats: %w", err)
+		}
+		return nil
+	}
+
+	if err := c.store.WithinTran(ctx, tran); err != nil {
+		if database.IsUniqueViolation(err, uniqueReviewAuthor) {
+			return Review{}, ErrDuplicateReview
+		}
+		return Review{}, fmt.Errorf("tran: %w", err)
+	}
+
+	return review, nil
+}
+
+// UpdateReview replaces the fields of a review that are set in the
+// UpdateReview value. Only the author of the review is allowed to change it.
+// Editing a hidden or rejected review sends it back to moderation.
+func (c Core) UpdateReview(ctx context.Context, userID string, beerID string, reviewID string, ur UpdateReview) (Review, error) {
+	if err := validate.Check(ur); err != nil {
+		return Review{}, fmt.Errorf("validating data: %w", err)
+	}
+
+	review, err := c.queryAuthorReview(ctx, userID, beerID, reviewID)
+	if err != nil {
+		return Review{}, err
+	}
+
+	// The score is either derived from a scoresheet or set on its own.
+	sheetChanged := ur.Scoresheet != nil || ur.Score != nil
+	switch {
+	case ur.Scoresheet != nil:
+		review.Scoresheet = ur.Scoresheet
+		review.Score = ur.Scoresheet.Score()
+	case ur.Score != nil:
+		review.Scoresheet = nil
+		review.Score = *ur.Score
+	}
+	if ur.Comment != nil {
+		review.Comment = *ur.Comment
+	}
+
+	// An edited review that was taken down goes back to the moderators.
+	if review.Status == ReviewHidden || review.Status == ReviewRejected {
+		review.Status = ReviewPending
+	}
+
+	tran := func(s Storer) error {
+		if err := s.UpdateReview(ctx, review); err != nil {
+			return fmt.Errorf("updateReview: %w", err)
+		}
+		if sheetChanged {
+			if err := saveScoresheet(ctx, s, review.ID, review.Scoresheet); err != nil {
+				return err
+			}
+		}
+		if err := s.UpdateBeerStats(ctx, review.BeerID); err != nil {
+			return fmt.Errorf("updateBeerStats: %w", err)
+		}
+		return nil
+	}
+
+	if err := c.store.WithinTran(ctx, tran); err != nil {
+		return Review{}, fmt.Errorf("tran: %w", err)
 	}
 
 	return review, nil
 }
 
-// QueryReviews gets all reviews for a beer from the database.
-func (c Core) QueryReviews(ctx context.Context, beerID string, page int, size int) ([]Review, error) {
+// DeleteReview soft deletes a review. Only the author of the review is
+// allowed to remove it.
+func (c Core) DeleteReview(ctx context.Context, userID string, beerID string, reviewID string) error {
+	review, err := c.queryAuthorReview(ctx, userID, beerID, reviewID)
+	if err != nil {
+		return err
+	}
+
+	tran := func(s Storer) error {
+		if err := s.DeleteReview(ctx, review.ID); err != nil {
+			return fmt.Errorf("deleteReview: %w", err)
+		}
+		if err := s.UpdateBeerStats(ctx, review.BeerID); err != nil {
+			return fmt.Errorf("updateBeerStats: %w", err)
+		}
+		return nil
+	}
+
+	if err := c.store.WithinTran(ctx, tran); err != nil {
+		return fmt.Errorf("tran: %w", err)
+	}
+
+	return nil
+}
+
+// QueryReviews gets all reviews for a beer from the database, in the
+// requested order.
+func (c Core) QueryReviews(ctx context.Context, beerID string, orderBy []order.By, page int, size int) ([]Review, error) {
 	if err := validate.CheckID(beerID); err != nil {
 		return nil, ErrInvalidID
 	}
 
-	reviews, err := c.store.QueryBeerReviews(ctx, beerID, page, size)
+	if err := order.Check(orderBy, reviewOrderByFields); err != nil {
+		return nil, err
+	}
+
+	reviews, err := c.store.QueryBeerReviews(ctx, beerID, orderBy, page, size)
 	if err != nil {
 		return nil, fmt.Errorf("queryBeerReviews: %w", err)
 	}
 
 	return reviews, nil
 }
+
+// QueryReviewsByCursor gets a page of reviews for a beer, positioned by the
+// cursor. Along with the reviews it returns the cursors to the pages around
+// it.
+func (c Core) QueryReviewsByCursor(ctx context.Context, beerID string, cur cursor.Cursor, size int) ([]Review, cursor.Page, error) {
+	if err := validate.CheckID(beerID); err != nil {
+		return nil, cursor.Page{}, ErrInvalidID
+	}
+
+	if err := order.Check(cur.OrderBy, reviewOrderByFields); err != nil {
+		return nil, cursor.Page{}, err
+	}
+
+	if err := cursor.CheckSize(size); err != nil {
+		return nil, cursor.Page{}, err
+	}
+
+	// Fetch one extra review to know if there is a page past this one.
+	reviews, err := c.store.QueryBeerReviewsByCursor(ctx, beerID, cur, size+1)
+	if err != nil {
+		return nil, cursor.Page{}, fmt.Errorf("queryBeerReviewsByCursor: %w", err)
+	}
+
+	reviews, page := cursor.Paginate(reviews, cur, size, reviewCursorValues)
+
+	return reviews, page, nil
+}
+
+// =========================================================================
+
+// QueryStats gets the aggregates of a beer, including the per section
+// averages of the scoresheets of its reviews.
+func (c Core) QueryStats(ctx context.Context, beerID string) (Stats, error) {
+	beer, err := c.QueryByID(ctx, beerID)
+	if err != nil {
+		return Stats{}, err
+	}
+
+	sheets, err := c.store.QueryScoresheetStats(ctx, beer.ID)
+	if err != nil {
+		return Stats{}, fmt.Errorf("queryScoresheetStats: %w", err)
+	}
+
+	stats := Stats{
+		BeerID:       beer.ID,
+		Score:        beer.Score,
+		ReviewCount:  beer.ReviewCount,
+		CheckinCount: beer.CheckinCount,
+		Scoresheet:   sheets,
+	}
+
+	return stats, nil
+}
+
+// saveScoresheet stores the scoresheet of a review, or removes the stored
+// one when the review has no scoresheet.
+func saveScoresheet(ctx context.Context, s Storer, reviewID string, sheet *Scoresheet) error {
+	if sheet == nil {
+		if err := s.DeleteScoresheet(ctx, reviewID); err != nil {
+			return fmt.Errorf("deleteScoresheet: %w", err)
+		}
+		return nil
+	}
+
+	if err := s.SaveScoresheet(ctx, reviewID, *sheet); err != nil {
+		return fmt.Errorf("saveScoresheet: %w", err)
+	}
+	return nil
+}
+
+// queryAuthorReview gets the review of a beer, making sure the user is its
+// author.
+func (c Core) queryAuthorReview(ctx context.Context, userID string, beerID string, reviewID string) (Review, error) {
+	if err := validate.CheckID(beerID); err != nil {
+		return Review{}, ErrInvalidID
+	}
+
+	if err := validate.CheckID(reviewID); err != nil {
+		return Review{}, ErrInvalidID
+	}
+
+	review, err := c.queryReview(ctx, reviewID)
+	if err != nil {
+		return Review{}, err
+	}
+
+	if review.BeerID != beerID {
+		return Review{}, ErrReviewNotFound
+	}
+
+	if review.UserID != userID {
+		return Review{}, ErrForbidden
+	}
+
+	return review, nil
+}
+
+// queryBrewery gets the brewery a beer references. An unknown brewery is
+// reported as a validation error on the brewery_id field.
+func (c Core) queryBrewery(ctx context.Context, breweryID string) (brewery.Brewery, error) {
+	brw, err := c.brewery.QueryByID(ctx, breweryID)
+	if err != nil {
+		if errors.Is(err, brewery.ErrNotFound) {
+			return brewery.Brewery{}, validate.FieldErrors{{Field: "brewery_id", Error: "brewery_id does not exist"}}
+		}
+		return brewery.Brewery{}, fmt.Errorf("querying brewery breweryID[%s]: %w", breweryID, err)
+	}
+
+	return brw, nil
+}
+
+// queryStyle gets the style a beer is brewed in from the style taxonomy. An
+// unknown style is reported as a validation error on the style field.
+func (c Core) queryStyle(ctx context.Context, name string) (style.Style, error) {
+	st, err := c.style.QueryByName(ctx, name)
+	if err != nil {
+		if errors.Is(err, style.ErrNotFound) {
+			return style.Style{}, validate.FieldErrors{{Field: "style", Error: "style does not exist"}}
+		}
+		return style.Style{}, fmt.Errorf("querying style name[%s]: %w", name, err)
+	}
+
+	return st, nil
+}
+
+// checkFilter validates the filter fields and the ranges they describe.
+func checkFilter(filter QueryFilter) error {
+	if err := validate.Check(filter); err != nil {
+		return fmt.Errorf("validating filter: %w", err)
+	}
+
+	if filter.MinABV != nil && filter.MaxABV != nil && *filter.MinABV > *filter.MaxABV {
+		return validate.FieldErrors{{Field: "max_abv", Error: "max_abv must be greater than or equal to min_abv"}}
+	}
+
+	if filter.CreatedAfter != nil && filter.CreatedBefore != nil && filter.CreatedAfter.After(*filter.CreatedBefore) {
+		return validate.FieldErrors{{Field: "created_before", Error: "created_before must be after created_after"}}
+	}
+
+	return nil
+}
+
+// normalizeTags returns the tag names in their canonical form, without
+// duplicates and sorted, the way they are read back from the database.
+func normalizeTags(names []string) []string {
+	tags := make([]string, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		name = tag.Normalize(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		tags = append(tags, name)
+	}
+	sort.Strings(tags)
+	return tags
+}
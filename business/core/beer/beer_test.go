@@ -2,6 +2,7 @@ package beer_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -10,7 +11,18 @@ import (
 	"github.com/google/uuid"
 	"github.com/phbpx/gobeers/business/core/beer"
 	"github.com/phbpx/gobeers/business/core/beer/stores/beerdb"
+	"github.com/phbpx/gobeers/business/core/brewery"
+	"github.com/phbpx/gobeers/business/core/brewery/stores/brewerydb"
+	"github.com/phbpx/gobeers/business/core/style"
+	"github.com/phbpx/gobeers/business/core/style/stores/styledb"
+	"github.com/phbpx/gobeers/business/core/tag"
+	"github.com/phbpx/gobeers/business/core/tag/stores/tagdb"
 	"github.com/phbpx/gobeers/business/data/dbtest"
+	"github.com/phbpx/gobeers/business/sys/blob"
+	"github.com/phbpx/gobeers/business/sys/blob/local"
+	"github.com/phbpx/gobeers/business/sys/cursor"
+	"github.com/phbpx/gobeers/business/sys/order"
+	"github.com/phbpx/gobeers/business/sys/validate"
 	"github.com/phbpx/gobeers/foundation/docker"
 )
 
@@ -32,7 +44,14 @@ func TestBeer(t *testing.T) {
 	log, db, teardown := dbtest.NewUnit(t, c, "testbeer")
 	t.Cleanup(teardown)
 
-	core := beer.NewCore(beerdb.NewStore(log, db))
+	breweryCore := brewery.NewCore(brewerydb.NewStore(log, db))
+	styleCore := style.NewCore(styledb.NewStore(log, db))
+	core := beer.NewCore(breweryCore, styleCore, local.NewStore(t.TempDir(), "http://localhost/images"), beerdb.NewStore(log, db))
+
+	brw, err := breweryCore.Create(context.Background(), brewery.NewBrewery{Name: "Test Brewery"}, time.Now())
+	if err != nil {
+		t.Fatalf("Should be able to add a brewery : %s", err)
+	}
 
 	t.Log("Given the need to work with Beer records.")
 	{
@@ -42,33 +61,33 @@ func TestBeer(t *testing.T) {
 
 			nb := beer.NewBeer{
 				Name:      "Test Beer",
-				Brewery:   "Test Brewery",
-				Style:     "Test Style",
+				BreweryID: brw.ID,
+				Style:     "American IPA",
 				ABV:       5.5,
 				ShortDesc: "Test Short Description",
 			}
 
-			beer, err := core.Create(ctx, nb)
+			b, err := core.Create(ctx, nb, false)
 			if err != nil {
 				t.Fatalf("\t [ERROR] Should be able to add a beer : %s", err)
 			}
 			t.Logf("\t [SUCCESS] Should be able to add a beer.")
 
-			saved, err := core.QueryByID(ctx, beer.ID)
+			saved, err := core.QueryByID(ctx, b.ID)
 			if err != nil {
 				t.Fatalf("\t [ERROR] Should be able to query a beer by id : %s", err)
 			}
 			t.Logf("\t [SUCCESS] Should be able to query a beer by id.")
 
-			beer.CreatedAt = time.Time{}
+			b.CreatedAt = time.Time{}
 			saved.CreatedAt = time.Time{}
 
-			if diff := cmp.Diff(beer, saved); diff != "" {
+			if diff := cmp.Diff(b, saved); diff != "" {
 				t.Fatalf("\t [ERROR] Should get back the same beer : %s", diff)
 			}
 			t.Logf("\t [SUCCESS] Should get back the same beer.")
 
-			beers, err := core.Query(ctx, 1, 10)
+			beers, err := core.Query(ctx, beer.QueryFilter{Name: &nb.Name}, []order.By{beer.DefaultBeerOrderBy}, 1, 10)
 			if err != nil {
 				t.Fatalf("\t [ERROR] Should be able to query beers : %s", err)
 			}
@@ -78,6 +97,137 @@ func TestBeer(t *testing.T) {
 				t.Fatalf("\t [ERROR] Should get back at least one beer.")
 			}
 			t.Logf("\t [SUCCESS] Should get back at least one beer.")
+
+			results, err := core.Search(ctx, "test brew", 1, 10)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to search beers : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should be able to search beers.")
+
+			if len(results) == 0 || results[0].ID != b.ID {
+				t.Fatalf("\t [ERROR] Should find the beer by partial words : %+v", results)
+			}
+			t.Logf("\t [SUCCESS] Should find the beer by partial words.")
+
+			name := "Updated Beer"
+			ub := beer.UpdateBeer{
+				Name: &name,
+			}
+
+			updated, err := core.Update(ctx, b.ID, ub)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to update a beer : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should be able to update a beer.")
+
+			saved, err = core.QueryByID(ctx, b.ID)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to query an updated beer by id : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should be able to query an updated beer by id.")
+
+			if saved.Name != name || saved.Brewery != brw.Name {
+				t.Fatalf("\t [ERROR] Should get back the updated beer : got %+v, exp %+v", saved, updated)
+			}
+			t.Logf("\t [SUCCESS] Should get back the updated beer.")
+
+			if err := core.Delete(ctx, b.ID); err != nil {
+				t.Fatalf("\t [ERROR] Should be able to delete a beer : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should be able to delete a beer.")
+
+			if _, err := core.QueryByID(ctx, b.ID); !errors.Is(err, beer.ErrNotFound) {
+				t.Fatalf("\t [ERROR] Should NOT be able to retrieve a deleted beer : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should NOT be able to retrieve a deleted beer.")
+		}
+	}
+
+	t.Log("Given the need to page through Beer records with a cursor.")
+	{
+		t.Logf("\tWhen handling a list of Beers.")
+		{
+			ctx := context.Background()
+
+			cursorBrw, err := breweryCore.Create(ctx, brewery.NewBrewery{Name: "Cursor Brewery"}, time.Now())
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to add a brewery : %s", err)
+			}
+			for _, name := range []string{"Cursor A", "Cursor B", "Cursor C"} {
+				nb := beer.NewBeer{
+					Name:      name,
+					BreweryID: cursorBrw.ID,
+					Style:     "American IPA",
+					ABV:       5.5,
+					ShortDesc: "Test Short Description",
+				}
+
+				if _, err := core.Create(ctx, nb, false); err != nil {
+					t.Fatalf("\t [ERROR] Should be able to add a beer : %s", err)
+				}
+			}
+
+			filter := beer.QueryFilter{BreweryID: &cursorBrw.ID}
+			start := cursor.Cursor{OrderBy: []order.By{beer.DefaultBeerOrderBy}}
+
+			if _, _, err := core.QueryByCursor(ctx, filter, start, -1); !validate.IsFieldErrors(err) {
+				t.Fatalf("\t [ERROR] Should NOT be able to query a page of invalid size : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should NOT be able to query a page of invalid size.")
+
+			first, page, err := core.QueryByCursor(ctx, filter, start, 2)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to query the first page : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should be able to query the first page.")
+
+			if len(first) != 2 || page.Next == nil || page.Prev != nil {
+				t.Fatalf("\t [ERROR] Should get back a full first page with a next cursor : %d %+v", len(first), page)
+			}
+			t.Logf("\t [SUCCESS] Should get back a full first page with a next cursor.")
+
+			second, page, err := core.QueryByCursor(ctx, filter, *page.Next, 2)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to query the second page : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should be able to query the second page.")
+
+			if len(second) != 1 || second[0].Name != "Cursor C" || page.Next != nil || page.Prev == nil {
+				t.Fatalf("\t [ERROR] Should get back the last beer with a prev cursor : %+v %+v", second, page)
+			}
+			t.Logf("\t [SUCCESS] Should get back the last beer with a prev cursor.")
+
+			back, _, err := core.QueryByCursor(ctx, filter, *page.Prev, 2)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to query the previous page : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should be able to query the previous page.")
+
+			if diff := cmp.Diff(first, back); diff != "" {
+				t.Fatalf("\t [ERROR] Should get back the first page : %s", diff)
+			}
+			t.Logf("\t [SUCCESS] Should get back the first page.")
+
+			suggestions, err := core.Suggest(ctx, "curs", 5)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to suggest beers : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should be able to suggest beers.")
+
+			var beers, breweries int
+			for _, s := range suggestions {
+				switch s.Kind {
+				case beer.SuggestionBeer:
+					beers++
+				case beer.SuggestionBrewery:
+					breweries++
+				}
+			}
+
+			if beers != 3 || breweries != 1 {
+				t.Fatalf("\t [ERROR] Should get back the beers and their brewery : %+v", suggestions)
+			}
+			t.Logf("\t [SUCCESS] Should get back the beers and their brewery.")
 		}
 	}
 
@@ -90,30 +240,46 @@ func TestBeer(t *testing.T) {
 
 			nb := beer.NewBeer{
 				Name:      "Test Beer",
-				Brewery:   "Test Brewery",
-				Style:     "Test Style",
+				BreweryID: brw.ID,
+				Style:     "American IPA",
 				ABV:       5.5,
 				ShortDesc: "Test Short Description",
 			}
 
-			b, err := core.Create(ctx, nb)
+			b, err := core.Create(ctx, nb, false)
 			if err != nil {
 				t.Fatalf("\t [ERROR] Should be able to add a beer : %s", err)
 			}
 
+			userID := uuid.NewString()
+
 			nr := beer.NewReview{
-				UserID:  uuid.NewString(),
 				Score:   5,
 				Comment: "Test Comment",
 			}
 
-			_, err = core.CreateReview(ctx, b.ID, nr, now)
+			rw, err := core.CreateReview(ctx, userID, b.ID, nr, false, now)
 			if err != nil {
 				t.Fatalf("\t [ERROR] Should be able to add a review : %s", err)
 			}
 			t.Logf("\t [SUCCESS] Should be able to add a review.")
 
-			reviews, err := core.QueryReviews(ctx, b.ID, 1, 10)
+			if _, err := core.CreateReview(ctx, userID, b.ID, nr, false, now); !errors.Is(err, beer.ErrDuplicateReview) {
+				t.Fatalf("\t [ERROR] Should NOT be able to review a beer twice : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should NOT be able to review a beer twice.")
+
+			upserted, err := core.CreateReview(ctx, userID, b.ID, nr, true, now)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to upsert a review : %s", err)
+			}
+
+			if upserted.ID != rw.ID {
+				t.Fatalf("\t [ERROR] Should get back the existing review : got %s, exp %s", upserted.ID, rw.ID)
+			}
+			t.Logf("\t [SUCCESS] Should be able to upsert a review.")
+
+			reviews, err := core.QueryReviews(ctx, b.ID, []order.By{beer.DefaultReviewOrderBy}, 1, 10)
 			if err != nil {
 				t.Fatalf("\t [ERROR] Should be able to query reviews : %s", err)
 			}
@@ -123,6 +289,1085 @@ func TestBeer(t *testing.T) {
 				t.Fatalf("\t [ERROR] Should get back at least one review.")
 			}
 			t.Logf("\t [SUCCESS] Should get back at least one review.")
+
+			reviewed, err := core.QueryByID(ctx, b.ID)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to query a reviewed beer by id : %s", err)
+			}
+
+			if reviewed.Score != nr.Score || reviewed.ReviewCount != 1 {
+				t.Fatalf("\t [ERROR] Should get back the review aggregates : score[%v] count[%d]", reviewed.Score, reviewed.ReviewCount)
+			}
+			t.Logf("\t [SUCCESS] Should get back the review aggregates.")
+
+			score := float32(3)
+			if _, err := core.UpdateReview(ctx, uuid.NewString(), b.ID, rw.ID, beer.UpdateReview{Score: &score}); !errors.Is(err, beer.ErrForbidden) {
+				t.Fatalf("\t [ERROR] Should NOT be able to update a review from another user : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should NOT be able to update a review from another user.")
+
+			if _, err := core.UpdateReview(ctx, userID, b.ID, rw.ID, beer.UpdateReview{Score: &score}); err != nil {
+				t.Fatalf("\t [ERROR] Should be able to update a review : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should be able to update a review.")
+
+			reviewed, err = core.QueryByID(ctx, b.ID)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to query a reviewed beer by id : %s", err)
+			}
+
+			if reviewed.Score != score {
+				t.Fatalf("\t [ERROR] Should get back the updated review aggregates : score[%v]", reviewed.Score)
+			}
+			t.Logf("\t [SUCCESS] Should get back the updated review aggregates.")
+
+			if err := core.DeleteReview(ctx, uuid.NewString(), b.ID, rw.ID); !errors.Is(err, beer.ErrForbidden) {
+				t.Fatalf("\t [ERROR] Should NOT be able to delete a review from another user : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should NOT be able to delete a review from another user.")
+
+			if err := core.DeleteReview(ctx, userID, b.ID, rw.ID); err != nil {
+				t.Fatalf("\t [ERROR] Should be able to delete a review : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should be able to delete a review.")
+		}
+	}
+
+	t.Log("Given the need to moderate Beer Review records.")
+	{
+		t.Logf("\tWhen handling a reported Beer Review.")
+		{
+			ctx := context.Background()
+			now := time.Date(2022, 2, 25, 0, 0, 0, 0, time.UTC)
+
+			nb := beer.NewBeer{
+				Name:      "Moderated Beer",
+				BreweryID: brw.ID,
+				Style:     "American IPA",
+				ABV:       5.5,
+				ShortDesc: "Test Short Description",
+			}
+
+			b, err := core.Create(ctx, nb, false)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to add a beer : %s", err)
+			}
+
+			authorID := uuid.NewString()
+
+			rw, err := core.CreateReview(ctx, authorID, b.ID, beer.NewReview{Score: 5, Comment: "Best beer ever"}, false, now)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to add a review : %s", err)
+			}
+
+			nr := beer.NewReport{Reason: "Written by the brewery"}
+
+			if _, err := core.ReportReview(ctx, authorID, rw.ID, nr, now); !errors.Is(err, beer.ErrForbidden) {
+				t.Fatalf("\t [ERROR] Should NOT be able to report its own review : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should NOT be able to report its own review.")
+
+			reporterID := uuid.NewString()
+			if _, err := core.ReportReview(ctx, reporterID, rw.ID, nr, now); err != nil {
+				t.Fatalf("\t [ERROR] Should be able to report a review : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should be able to report a review.")
+
+			if _, err := core.ReportReview(ctx, reporterID, rw.ID, nr, now); !errors.Is(err, beer.ErrDuplicateReport) {
+				t.Fatalf("\t [ERROR] Should NOT be able to report a review twice : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should NOT be able to report a review twice.")
+
+			for i := 1; i < beer.ReportHideThreshold; i++ {
+				if _, err := core.ReportReview(ctx, uuid.NewString(), rw.ID, nr, now); err != nil {
+					t.Fatalf("\t [ERROR] Should be able to report a review : %s", err)
+				}
+			}
+
+			reviews, err := core.QueryReviews(ctx, b.ID, []order.By{beer.DefaultReviewOrderBy}, 1, 10)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to query reviews : %s", err)
+			}
+
+			if len(reviews) != 0 {
+				t.Fatalf("\t [ERROR] Should hide a review reaching the report threshold : %+v", reviews)
+			}
+			t.Logf("\t [SUCCESS] Should hide a review reaching the report threshold.")
+
+			queue, err := core.QueryModerationQueue(ctx, 1, 10)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to query the moderation queue : %s", err)
+			}
+
+			if len(queue) == 0 || queue[0].ID != rw.ID || queue[0].Status != beer.ReviewHidden {
+				t.Fatalf("\t [ERROR] Should get back the hidden review in the moderation queue : %+v", queue)
+			}
+			t.Logf("\t [SUCCESS] Should get back the hidden review in the moderation queue.")
+
+			moderatorID := uuid.NewString()
+
+			approved, err := core.ApproveReview(ctx, moderatorID, rw.ID, beer.ModerationDecision{}, now)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to approve a review : %s", err)
+			}
+
+			if approved.Status != beer.ReviewPublished || approved.ReportCount != 0 {
+				t.Fatalf("\t [ERROR] Should publish an approved review : %+v", approved)
+			}
+			t.Logf("\t [SUCCESS] Should publish an approved review.")
+
+			rejected, err := core.RejectReview(ctx, moderatorID, rw.ID, beer.ModerationDecision{Reason: "Conflict of interest"}, now)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to reject a review : %s", err)
+			}
+
+			if rejected.Status != beer.ReviewRejected || rejected.ModerationReason != "Conflict of interest" || rejected.ModeratedBy != moderatorID {
+				t.Fatalf("\t [ERROR] Should record the decision on a rejected review : %+v", rejected)
+			}
+			t.Logf("\t [SUCCESS] Should be able to reject a review.")
+
+			reviewed, err := core.QueryByID(ctx, b.ID)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to query a reviewed beer by id : %s", err)
+			}
+
+			if reviewed.ReviewCount != 0 {
+				t.Fatalf("\t [ERROR] Should NOT count a rejected review in the aggregates : count[%d]", reviewed.ReviewCount)
+			}
+			t.Logf("\t [SUCCESS] Should NOT count a rejected review in the aggregates.")
+		}
+	}
+
+	t.Log("Given the need to rank Beer Review records by helpfulness.")
+	{
+		t.Logf("\tWhen handling votes on Beer Reviews.")
+		{
+			ctx := context.Background()
+			now := time.Date(2022, 2, 25, 0, 0, 0, 0, time.UTC)
+
+			nb := beer.NewBeer{
+				Name:      "Voted Beer",
+				BreweryID: brw.ID,
+				Style:     "American IPA",
+				ABV:       5.5,
+				ShortDesc: "Test Short Description",
+			}
+
+			b, err := core.Create(ctx, nb, false)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to add a beer : %s", err)
+			}
+
+			nr := beer.NewReview{Score: 4, Comment: "Test Comment"}
+
+			helpful, err := core.CreateReview(ctx, uuid.NewString(), b.ID, nr, false, now)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to add a review : %s", err)
+			}
+
+			unhelpful, err := core.CreateReview(ctx, uuid.NewString(), b.ID, nr, false, now.Add(time.Hour))
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to add a review : %s", err)
+			}
+
+			yes, no := true, false
+
+			if _, err := core.VoteReview(ctx, helpful.UserID, helpful.ID, beer.NewVote{Helpful: &yes}, now); !errors.Is(err, beer.ErrForbidden) {
+				t.Fatalf("\t [ERROR] Should NOT be able to vote on its own review : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should NOT be able to vote on its own review.")
+
+			for i := 0; i < 3; i++ {
+				if _, err := core.VoteReview(ctx, uuid.NewString(), helpful.ID, beer.NewVote{Helpful: &yes}, now); err != nil {
+					t.Fatalf("\t [ERROR] Should be able to vote on a review : %s", err)
+				}
+			}
+
+			voterID := uuid.NewString()
+			if _, err := core.VoteReview(ctx, voterID, unhelpful.ID, beer.NewVote{Helpful: &yes}, now); err != nil {
+				t.Fatalf("\t [ERROR] Should be able to vote on a review : %s", err)
+			}
+
+			voted, err := core.VoteReview(ctx, voterID, unhelpful.ID, beer.NewVote{Helpful: &no}, now)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to change a vote : %s", err)
+			}
+
+			if voted.HelpfulCount != 0 || voted.UnhelpfulCount != 1 {
+				t.Fatalf("\t [ERROR] Should replace the previous vote : %+v", voted)
+			}
+			t.Logf("\t [SUCCESS] Should replace the previous vote.")
+
+			orderBy := []order.By{order.NewBy(beer.OrderByHelpfulness, order.DESC)}
+
+			reviews, err := core.QueryReviews(ctx, b.ID, orderBy, 1, 10)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to query reviews by helpfulness : %s", err)
+			}
+
+			if len(reviews) != 2 || reviews[0].ID != helpful.ID || reviews[0].Helpfulness <= 0 {
+				t.Fatalf("\t [ERROR] Should get back the most helpful review first : %+v", reviews)
+			}
+			t.Logf("\t [SUCCESS] Should get back the most helpful review first.")
+		}
+	}
+
+	t.Log("Given the need to score Beer Reviews with a scoresheet.")
+	{
+		t.Logf("\tWhen handling a Beer Review with a scoresheet.")
+		{
+			ctx := context.Background()
+			now := time.Date(2022, 2, 25, 0, 0, 0, 0, time.UTC)
+
+			nb := beer.NewBeer{
+				Name:      "Judged Beer",
+				BreweryID: brw.ID,
+				Style:     "American IPA",
+				ABV:       5.5,
+				ShortDesc: "Test Short Description",
+			}
+
+			b, err := core.Create(ctx, nb, false)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to add a beer : %s", err)
+			}
+
+			invalid := beer.NewReview{
+				Comment:    "Test Comment",
+				Scoresheet: &beer.Scoresheet{Aroma: 13},
+			}
+
+			if _, err := core.CreateReview(ctx, uuid.NewString(), b.ID, invalid, false, now); !validate.IsFieldErrors(err) {
+				t.Fatalf("\t [ERROR] Should NOT be able to add a scoresheet over the section maximum : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should NOT be able to add a scoresheet over the section maximum.")
+
+			nr := beer.NewReview{
+				Comment: "Test Comment",
+				Scoresheet: &beer.Scoresheet{
+					Aroma:      10,
+					Appearance: 3,
+					Flavor:     16,
+					Mouthfeel:  4,
+					Overall:    7,
+				},
+			}
+
+			rw, err := core.CreateReview(ctx, uuid.NewString(), b.ID, nr, false, now)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to add a review with a scoresheet : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should be able to add a review with a scoresheet.")
+
+			if rw.Score != 4 {
+				t.Fatalf("\t [ERROR] Should derive the score from the scoresheet : %v", rw.Score)
+			}
+			t.Logf("\t [SUCCESS] Should derive the score from the scoresheet.")
+
+			saved, err := core.QueryReviews(ctx, b.ID, []order.By{beer.DefaultReviewOrderBy}, 1, 10)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to query reviews : %s", err)
+			}
+
+			if len(saved) != 1 || saved[0].Scoresheet == nil || *saved[0].Scoresheet != *nr.Scoresheet {
+				t.Fatalf("\t [ERROR] Should get back the review scoresheet : %+v", saved)
+			}
+			t.Logf("\t [SUCCESS] Should get back the review scoresheet.")
+
+			if _, err := core.CreateReview(ctx, uuid.NewString(), b.ID, beer.NewReview{Score: 2, Comment: "Test Comment"}, false, now); err != nil {
+				t.Fatalf("\t [ERROR] Should be able to add a review without a scoresheet : %s", err)
+			}
+
+			stats, err := core.QueryStats(ctx, b.ID)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to query the beer stats : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should be able to query the beer stats.")
+
+			if stats.ReviewCount != 2 || stats.Scoresheet.Count != 1 || stats.Scoresheet.Flavor != 16 || stats.Scoresheet.Total != 40 {
+				t.Fatalf("\t [ERROR] Should get back the scoresheet averages : %+v", stats)
+			}
+			t.Logf("\t [SUCCESS] Should get back the scoresheet averages.")
+		}
+	}
+}
+
+func TestSimilarBeers(t *testing.T) {
+	log, db, teardown := dbtest.NewUnit(t, c, "testsimilarbeers")
+	t.Cleanup(teardown)
+
+	breweryCore := brewery.NewCore(brewerydb.NewStore(log, db))
+	styleCore := style.NewCore(styledb.NewStore(log, db))
+	core := beer.NewCore(breweryCore, styleCore, local.NewStore(t.TempDir(), "http://localhost/images"), beerdb.NewStore(log, db))
+
+	t.Log("Given the need to find similar Beers.")
+	{
+		t.Logf("\tWhen comparing Beer attributes.")
+		{
+			ctx := context.Background()
+			now := time.Date(2022, 2, 25, 0, 0, 0, 0, time.UTC)
+
+			brw, err := breweryCore.Create(ctx, brewery.NewBrewery{Name: "Test Brewery"}, now)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to add a brewery : %s", err)
+			}
+
+			other, err := breweryCore.Create(ctx, brewery.NewBrewery{Name: "Other Brewery"}, now)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to add a brewery : %s", err)
+			}
+
+			nbs := []beer.NewBeer{
+				{Name: "Hazy IPA", BreweryID: brw.ID, Style: "American IPA", ABV: 6.5, ShortDesc: "Juicy hazy IPA with tropical hops"},
+				{Name: "Juicy IPA", BreweryID: brw.ID, Style: "American IPA", ABV: 6.2, ShortDesc: "Juicy IPA bursting with tropical hops"},
+				{Name: "Rye IPA", BreweryID: other.ID, Style: "Specialty IPA", ABV: 7, ShortDesc: "Spicy rye malt and piney hops"},
+				{Name: "Dry Stout", BreweryID: other.ID, Style: "Irish Stout", ABV: 4.2, ShortDesc: "Roasted coffee notes and a dry finish"},
+			}
+
+			var beers []beer.Beer
+			for _, nb := range nbs {
+				b, err := core.Create(ctx, nb, false)
+				if err != nil {
+					t.Fatalf("\t [ERROR] Should be able to add a beer : %s", err)
+				}
+				beers = append(beers, b)
+			}
+
+			similar, err := core.QuerySimilar(ctx, beers[0].ID, 10)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to query similar beers : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should be able to query similar beers.")
+
+			if len(similar) != len(beers)-1 {
+				t.Fatalf("\t [ERROR] Should get back every other beer : %+v", similar)
+			}
+			t.Logf("\t [SUCCESS] Should get back every other beer.")
+
+			for i, b := range beers[1:] {
+				if similar[i].ID != b.ID {
+					t.Fatalf("\t [ERROR] Should get back the closest beers first : %+v", similar)
+				}
+			}
+			t.Logf("\t [SUCCESS] Should get back the closest beers first.")
+
+			if similar[0].Similarity <= similar[1].Similarity || similar[1].Similarity <= similar[2].Similarity || similar[0].Similarity > 1 {
+				t.Fatalf("\t [ERROR] Should get back decreasing similarity scores : %+v", similar)
+			}
+			t.Logf("\t [SUCCESS] Should get back decreasing similarity scores.")
+
+			if _, err := core.QuerySimilar(ctx, uuid.NewString(), 10); !errors.Is(err, beer.ErrNotFound) {
+				t.Fatalf("\t [ERROR] Should NOT be able to query beers similar to an unknown beer : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should NOT be able to query beers similar to an unknown beer.")
+		}
+	}
+}
+
+func TestTrendingBeers(t *testing.T) {
+	log, db, teardown := dbtest.NewUnit(t, c, "testtrendingbeers")
+	t.Cleanup(teardown)
+
+	breweryCore := brewery.NewCore(brewerydb.NewStore(log, db))
+	styleCore := style.NewCore(styledb.NewStore(log, db))
+	core := beer.NewCore(breweryCore, styleCore, local.NewStore(t.TempDir(), "http://localhost/images"), beerdb.NewStore(log, db))
+
+	t.Log("Given the need to find trending Beers.")
+	{
+		t.Logf("\tWhen handling recent Beer Reviews.")
+		{
+			ctx := context.Background()
+			now := time.Date(2022, 2, 25, 0, 0, 0, 0, time.UTC)
+			day := 24 * time.Hour
+
+			brw, err := breweryCore.Create(ctx, brewery.NewBrewery{Name: "Test Brewery"}, now)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to add a brewery : %s", err)
+			}
+
+			// Reviews ages of every beer: a new hit, a steady seller and a
+			// beer nobody reviews anymore.
+			ages := map[string][]time.Duration{
+				"Hit Beer":    {day, 2 * day, 3 * day},
+				"Steady Beer": {day, 8 * day, 9 * day, 15 * day, 16 * day, 22 * day, 23 * day},
+				"Old Beer":    {10 * day, 20 * day},
+			}
+
+			beers := make(map[string]beer.Beer)
+			for _, name := range []string{"Hit Beer", "Steady Beer", "Old Beer"} {
+				nb := beer.NewBeer{
+					Name:      name,
+					BreweryID: brw.ID,
+					Style:     "American IPA",
+					ABV:       5.5,
+					ShortDesc: "Test Short Description",
+				}
+
+				b, err := core.Create(ctx, nb, false)
+				if err != nil {
+					t.Fatalf("\t [ERROR] Should be able to add a beer : %s", err)
+				}
+				beers[name] = b
+
+				for _, age := range ages[name] {
+					nr := beer.NewReview{Score: 4, Comment: "Test Comment"}
+					if _, err := core.CreateReview(ctx, uuid.NewString(), b.ID, nr, false, now.Add(-age)); err != nil {
+						t.Fatalf("\t [ERROR] Should be able to add a review : %s", err)
+					}
+				}
+			}
+
+			if _, err := core.QueryTrending(ctx, time.Minute, 10, now); !validate.IsFieldErrors(err) {
+				t.Fatalf("\t [ERROR] Should NOT be able to query trending beers over a too short window : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should NOT be able to query trending beers over a too short window.")
+
+			trending, err := core.QueryTrending(ctx, 7*day, 10, now)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to query trending beers : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should be able to query trending beers.")
+
+			if len(trending) != 2 || trending[0].ID != beers["Hit Beer"].ID || trending[1].ID != beers["Steady Beer"].ID {
+				t.Fatalf("\t [ERROR] Should get back the beers reviewed within the window, hottest first : %+v", trending)
+			}
+			t.Logf("\t [SUCCESS] Should get back the beers reviewed within the window, hottest first.")
+
+			if trending[0].RecentReviews != 3 || trending[0].TrendScore <= trending[1].TrendScore {
+				t.Fatalf("\t [ERROR] Should get back the trend scores : %+v", trending)
+			}
+			t.Logf("\t [SUCCESS] Should get back the trend scores.")
+		}
+	}
+}
+
+func TestTopBeers(t *testing.T) {
+	log, db, teardown := dbtest.NewUnit(t, c, "testtopbeers")
+	t.Cleanup(teardown)
+
+	breweryCore := brewery.NewCore(brewerydb.NewStore(log, db))
+	styleCore := style.NewCore(styledb.NewStore(log, db))
+	core := beer.NewCore(breweryCore, styleCore, local.NewStore(t.TempDir(), "http://localhost/images"), beerdb.NewStore(log, db))
+
+	t.Log("Given the need to rank the best Beers.")
+	{
+		t.Logf("\tWhen handling Beers with few and many Reviews.")
+		{
+			ctx := context.Background()
+			now := time.Date(2022, 2, 25, 0, 0, 0, 0, time.UTC)
+
+			brw, err := breweryCore.Create(ctx, brewery.NewBrewery{Name: "Test Brewery"}, now)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to add a brewery : %s", err)
+			}
+
+			add := func(name string, style string, scores ...float32) beer.Beer {
+				nb := beer.NewBeer{
+					Name:      name,
+					BreweryID: brw.ID,
+					Style:     style,
+					ABV:       5.5,
+					ShortDesc: "Test Short Description",
+				}
+
+				b, err := core.Create(ctx, nb, false)
+				if err != nil {
+					t.Fatalf("\t [ERROR] Should be able to add a beer : %s", err)
+				}
+
+				for _, score := range scores {
+					nr := beer.NewReview{Score: score, Comment: "Test Comment"}
+					if _, err := core.CreateReview(ctx, uuid.NewString(), b.ID, nr, false, now); err != nil {
+						t.Fatalf("\t [ERROR] Should be able to add a review : %s", err)
+					}
+				}
+
+				return b
+			}
+
+			var crowdScores []float32
+			for i := 0; i < 20; i++ {
+				score := float32(5)
+				if i%10 < 3 {
+					score = 4
+				}
+				crowdScores = append(crowdScores, score)
+			}
+
+			var mehScores []float32
+			for i := 0; i < 10; i++ {
+				mehScores = append(mehScores, 2)
+			}
+
+			single := add("Single Review", "American IPA", 5)
+			crowd := add("Crowd Favorite", "American IPA", crowdScores...)
+			meh := add("Meh Stout", "Irish Stout", mehScores...)
+			add("Unreviewed", "American IPA")
+
+			if err := core.RefreshRatings(ctx, 10, now); err != nil {
+				t.Fatalf("\t [ERROR] Should be able to refresh the ratings : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should be able to refresh the ratings.")
+
+			top, err := core.QueryTop(ctx, beer.QueryFilter{}, 1, 10)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to query the top beers : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should be able to query the top beers.")
+
+			if len(top) != 3 || top[0].ID != crowd.ID || top[1].ID != single.ID || top[2].ID != meh.ID {
+				t.Fatalf("\t [ERROR] Should rank a beer with many good reviews over a single perfect one : %+v", top)
+			}
+			t.Logf("\t [SUCCESS] Should rank a beer with many good reviews over a single perfect one.")
+
+			if top[1].Rating >= 5 || top[1].Rating <= top[2].Rating {
+				t.Fatalf("\t [ERROR] Should pull the ratings towards the mean : %+v", top)
+			}
+			t.Logf("\t [SUCCESS] Should pull the ratings towards the mean.")
+
+			stout := "Irish Stout"
+			styled, err := core.QueryTop(ctx, beer.QueryFilter{Style: &stout}, 1, 10)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to query the top beers of a style : %s", err)
+			}
+
+			if len(styled) != 1 || styled[0].ID != meh.ID {
+				t.Fatalf("\t [ERROR] Should get back the top beers of a style : %+v", styled)
+			}
+			t.Logf("\t [SUCCESS] Should get back the top beers of a style.")
+		}
+	}
+}
+
+func TestBeerImages(t *testing.T) {
+	log, db, teardown := dbtest.NewUnit(t, c, "testbeerimages")
+	t.Cleanup(teardown)
+
+	images := local.NewStore(t.TempDir(), "http://localhost/images")
+
+	breweryCore := brewery.NewCore(brewerydb.NewStore(log, db))
+	styleCore := style.NewCore(styledb.NewStore(log, db))
+	core := beer.NewCore(breweryCore, styleCore, images, beerdb.NewStore(log, db))
+
+	t.Log("Given the need to work with Beer images.")
+	{
+		t.Logf("\tWhen handling a single Beer image.")
+		{
+			ctx := context.Background()
+			now := time.Date(2022, 2, 25, 0, 0, 0, 0, time.UTC)
+
+			brw, err := breweryCore.Create(ctx, brewery.NewBrewery{Name: "Test Brewery"}, now)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to add a brewery : %s", err)
+			}
+
+			nb := beer.NewBeer{
+				Name:      "Test Beer",
+				BreweryID: brw.ID,
+				Style:     "American IPA",
+				ABV:       5.5,
+				ShortDesc: "Test Short Description",
+			}
+
+			b, err := core.Create(ctx, nb, false)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to add a beer : %s", err)
+			}
+
+			invalid := beer.NewImage{ContentType: "text/plain", Data: []byte("not an image")}
+			if _, err := core.AddImage(ctx, b.ID, invalid, now); !validate.IsFieldErrors(err) {
+				t.Fatalf("\t [ERROR] Should NOT be able to add an image of an unsupported type : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should NOT be able to add an image of an unsupported type.")
+
+			ni := beer.NewImage{ContentType: "image/png", Data: []byte("\x89PNG\r\n\x1a\ntest image")}
+			image, err := core.AddImage(ctx, b.ID, ni, now)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to add an image : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should be able to add an image.")
+
+			if _, err := images.Get(ctx, image.Key); err != nil {
+				t.Fatalf("\t [ERROR] Should be able to get the image blob : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should be able to get the image blob.")
+
+			saved, err := core.QueryByID(ctx, b.ID)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to query a beer by id : %s", err)
+			}
+
+			if len(saved.ImageURLs) != 1 || saved.ImageURLs[0] != images.URL(image.Key) {
+				t.Fatalf("\t [ERROR] Should get back the beer image urls : %+v", saved.ImageURLs)
+			}
+			t.Logf("\t [SUCCESS] Should get back the beer image urls.")
+
+			if err := core.Delete(ctx, b.ID); err != nil {
+				t.Fatalf("\t [ERROR] Should be able to delete a beer : %s", err)
+			}
+
+			if _, err := images.Get(ctx, image.Key); err != nil {
+				t.Fatalf("\t [ERROR] Should keep the image blobs of a deleted beer : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should keep the image blobs of a deleted beer.")
+
+			if err := core.Purge(ctx, time.Now().Add(time.Hour)); err != nil {
+				t.Fatalf("\t [ERROR] Should be able to purge the deleted beers : %s", err)
+			}
+
+			if _, err := images.Get(ctx, image.Key); !errors.Is(err, blob.ErrNotFound) {
+				t.Fatalf("\t [ERROR] Should delete the image blobs along with the purged beer : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should delete the image blobs along with the purged beer.")
+		}
+	}
+}
+
+func TestBeerTags(t *testing.T) {
+	log, db, teardown := dbtest.NewUnit(t, c, "testbeertags")
+	t.Cleanup(teardown)
+
+	breweryCore := brewery.NewCore(brewerydb.NewStore(log, db))
+	styleCore := style.NewCore(styledb.NewStore(log, db))
+	tagCore := tag.NewCore(tagdb.NewStore(log, db))
+	core := beer.NewCore(breweryCore, styleCore, local.NewStore(t.TempDir(), "http://localhost/images"), beerdb.NewStore(log, db))
+
+	t.Log("Given the need to tag Beer records.")
+	{
+		t.Logf("\tWhen filtering beers by tags.")
+		{
+			ctx := context.Background()
+			now := time.Date(2022, 2, 25, 0, 0, 0, 0, time.UTC)
+
+			brw, err := breweryCore.Create(ctx, brewery.NewBrewery{Name: "Test Brewery"}, now)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to add a brewery : %s", err)
+			}
+
+			if _, err := tagCore.Create(ctx, tag.NewTag{Name: "gluten-free"}, now); err != nil {
+				t.Fatalf("\t [ERROR] Should be able to add a curated tag : %s", err)
+			}
+
+			newBeer := func(name string, tags ...string) beer.Beer {
+				nb := beer.NewBeer{
+					Name:      name,
+					BreweryID: brw.ID,
+					Style:     "American IPA",
+					ABV:       5.5,
+					ShortDesc: "Test Short Description",
+					Tags:      tags,
+				}
+
+				b, err := core.Create(ctx, nb, false)
+				if err != nil {
+					t.Fatalf("\t [ERROR] Should be able to add a tagged beer : %s", err)
+				}
+				return b
+			}
+
+			aged := newBeer("Aged Sour", "Barrel Aged", "sour", "SOUR")
+			sour := newBeer("Sour", "sour", "gluten-free")
+			newBeer("Plain")
+			t.Logf("\t [SUCCESS] Should be able to add tagged beers.")
+
+			if diff := cmp.Diff([]string{"barrel-aged", "sour"}, aged.Tags); diff != "" {
+				t.Fatalf("\t [ERROR] Should normalize the beer tags : %s", diff)
+			}
+			t.Logf("\t [SUCCESS] Should normalize the beer tags.")
+
+			saved, err := core.QueryByID(ctx, aged.ID)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to query a beer by id : %s", err)
+			}
+
+			if diff := cmp.Diff(aged.Tags, saved.Tags); diff != "" {
+				t.Fatalf("\t [ERROR] Should get back the beer tags : %s", diff)
+			}
+			t.Logf("\t [SUCCESS] Should get back the beer tags.")
+
+			filter := beer.QueryFilter{Tags: []string{"barrel-aged", "gluten-free"}}
+			beers, err := core.Query(ctx, filter, []order.By{beer.DefaultBeerOrderBy}, 1, 10)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to query beers with any of the tags : %s", err)
+			}
+
+			if len(beers) != 2 || beers[0].ID != aged.ID || beers[1].ID != sour.ID {
+				t.Fatalf("\t [ERROR] Should get back the beers with any of the tags : %+v", beers)
+			}
+			t.Logf("\t [SUCCESS] Should get back the beers with any of the tags.")
+
+			filter = beer.QueryFilter{Tags: []string{"Sour", "gluten-free"}, TagMatch: beer.TagMatchAll}
+			beers, err = core.Query(ctx, filter, []order.By{beer.DefaultBeerOrderBy}, 1, 10)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to query beers with all of the tags : %s", err)
+			}
+
+			if len(beers) != 1 || beers[0].ID != sour.ID {
+				t.Fatalf("\t [ERROR] Should get back the beers with all of the tags : %+v", beers)
+			}
+			t.Logf("\t [SUCCESS] Should get back the beers with all of the tags.")
+
+			tags := []string{}
+			if _, err := core.Update(ctx, sour.ID, beer.UpdateBeer{Tags: &tags}); err != nil {
+				t.Fatalf("\t [ERROR] Should be able to untag a beer : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should be able to untag a beer.")
+
+			cloud, err := tagCore.QueryCloud(ctx, 10)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to query the tag cloud : %s", err)
+			}
+
+			if len(cloud) != 2 || cloud[0].Name != "barrel-aged" || cloud[0].BeerCount != 1 || cloud[1].Name != "sour" {
+				t.Fatalf("\t [ERROR] Should get back the tags in use with their counts : %+v", cloud)
+			}
+			t.Logf("\t [SUCCESS] Should get back the tags in use with their counts.")
+		}
+	}
+}
+
+func TestDuplicateBeers(t *testing.T) {
+	log, db, teardown := dbtest.NewUnit(t, c, "testduplicatebeers")
+	t.Cleanup(teardown)
+
+	breweryCore := brewery.NewCore(brewerydb.NewStore(log, db))
+	styleCore := style.NewCore(styledb.NewStore(log, db))
+	core := beer.NewCore(breweryCore, styleCore, local.NewStore(t.TempDir(), "http://localhost/images"), beerdb.NewStore(log, db))
+
+	t.Log("Given the need to prevent duplicate Beer records.")
+	{
+		t.Logf("\tWhen normalizing beer names.")
+		{
+			names := map[string]string{
+				"Café India Pale Ale":  "cafe ipa",
+				"  cafe   IPA!! ":      "cafe ipa",
+				"Hop & Grain E.S.B.":   "hop and grain e s b",
+				"Extra Special Bitter": "esb",
+				"Pale Ale":             "pale ale",
+				"Kölsch":               "kolsch",
+				"Weißbier":             "weissbier",
+			}
+
+			for name, exp := range names {
+				if got := beer.NormalizeName(name); got != exp {
+					t.Fatalf("\t [ERROR] Should normalize %q to %q : got %q", name, exp, got)
+				}
+			}
+			t.Logf("\t [SUCCESS] Should normalize beer names.")
+		}
+
+		t.Logf("\tWhen adding the same Beer twice.")
+		{
+			ctx := context.Background()
+			now := time.Date(2022, 2, 25, 0, 0, 0, 0, time.UTC)
+
+			brw, err := breweryCore.Create(ctx, brewery.NewBrewery{Name: "Test Brewery"}, now)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to add a brewery : %s", err)
+			}
+
+			other, err := breweryCore.Create(ctx, brewery.NewBrewery{Name: "Other Brewery"}, now)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to add a brewery : %s", err)
+			}
+
+			nb := beer.NewBeer{
+				Name:      "Café India Pale Ale",
+				BreweryID: brw.ID,
+				Style:     "American IPA",
+				ABV:       6.5,
+				ShortDesc: "Test Short Description",
+			}
+
+			b, err := core.Create(ctx, nb, false)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to add a beer : %s", err)
+			}
+
+			nb.Name = "cafe IPA!"
+
+			var de *beer.DuplicateError
+			if _, err := core.Create(ctx, nb, false); !errors.As(err, &de) || de.BeerID != b.ID {
+				t.Fatalf("\t [ERROR] Should NOT be able to add a duplicate beer : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should NOT be able to add a duplicate beer.")
+
+			if !errors.Is(de, beer.ErrDuplicateBeer) {
+				t.Fatalf("\t [ERROR] Should match ErrDuplicateBeer : %s", de)
+			}
+			t.Logf("\t [SUCCESS] Should match ErrDuplicateBeer.")
+
+			forced, err := core.Create(ctx, nb, true)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to force a duplicate beer : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should be able to force a duplicate beer.")
+
+			saved, err := core.QueryByID(ctx, forced.ID)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to query a forced beer by id : %s", err)
+			}
+
+			if !saved.Forced || saved.NormalizedName != b.NormalizedName {
+				t.Fatalf("\t [ERROR] Should keep the normalized name of a forced beer : %+v", saved)
+			}
+			t.Logf("\t [SUCCESS] Should keep the normalized name of a forced beer.")
+
+			nb.BreweryID = other.ID
+			if _, err := core.Create(ctx, nb, false); err != nil {
+				t.Fatalf("\t [ERROR] Should be able to add a beer with the same name to another brewery : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should be able to add a beer with the same name to another brewery.")
+
+			nb.Name = "Pale Ale"
+			nb.BreweryID = brw.ID
+			pale, err := core.Create(ctx, nb, false)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to add a beer : %s", err)
+			}
+
+			name := "CAFÉ IPA"
+			if _, err := core.Update(ctx, pale.ID, beer.UpdateBeer{Name: &name}); !errors.As(err, &de) || de.BeerID != b.ID {
+				t.Fatalf("\t [ERROR] Should NOT be able to rename a beer as a duplicate : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should NOT be able to rename a beer as a duplicate.")
+
+			if _, err := core.Update(ctx, forced.ID, beer.UpdateBeer{Name: &name}); err != nil {
+				t.Fatalf("\t [ERROR] Should be able to rename a forced duplicate : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should be able to rename a forced duplicate.")
+		}
+	}
+}
+
+func TestMergeBeers(t *testing.T) {
+	log, db, teardown := dbtest.NewUnit(t, c, "testmergebeers")
+	t.Cleanup(teardown)
+
+	breweryCore := brewery.NewCore(brewerydb.NewStore(log, db))
+	styleCore := style.NewCore(styledb.NewStore(log, db))
+	core := beer.NewCore(breweryCore, styleCore, local.NewStore(t.TempDir(), "http://localhost/images"), beerdb.NewStore(log, db))
+
+	t.Log("Given the need to merge duplicate Beer records.")
+	{
+		t.Logf("\tWhen merging a duplicate Beer.")
+		{
+			ctx := context.Background()
+			now := time.Date(2022, 2, 25, 0, 0, 0, 0, time.UTC)
+
+			brw, err := breweryCore.Create(ctx, brewery.NewBrewery{Name: "Test Brewery"}, now)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to add a brewery : %s", err)
+			}
+
+			newBeer := func(name string, tags ...string) beer.Beer {
+				nb := beer.NewBeer{
+					Name:      name,
+					BreweryID: brw.ID,
+					Style:     "American IPA",
+					ABV:       6.5,
+					ShortDesc: "Test Short Description",
+					Tags:      tags,
+				}
+
+				b, err := core.Create(ctx, nb, true)
+				if err != nil {
+					t.Fatalf("\t [ERROR] Should be able to add a beer : %s", err)
+				}
+				return b
+			}
+
+			canonical := newBeer("Test IPA", "hoppy")
+			duplicate := newBeer("Test I.P.A.", "hazy")
+			other := newBeer("Test India Pale Ale")
+
+			both := uuid.NewString()
+			reviews := []struct {
+				userID string
+				beerID string
+				score  float32
+			}{
+				{both, canonical.ID, 4},
+				{both, duplicate.ID, 2},
+				{uuid.NewString(), duplicate.ID, 5},
+			}
+
+			for _, rv := range reviews {
+				if _, err := core.CreateReview(ctx, rv.userID, rv.beerID, beer.NewReview{Score: rv.score, Comment: "Test comment"}, false, now); err != nil {
+					t.Fatalf("\t [ERROR] Should be able to add a review : %s", err)
+				}
+			}
+
+			merged, err := core.Merge(ctx, canonical.ID, beer.MergeBeer{DuplicateID: duplicate.ID}, now)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to merge a beer : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should be able to merge a beer.")
+
+			if merged.ReviewCount != 2 || merged.Score != 4.5 {
+				t.Fatalf("\t [ERROR] Should keep one review per user and recompute the aggregates : %+v", merged)
+			}
+			t.Logf("\t [SUCCESS] Should keep one review per user and recompute the aggregates.")
+
+			if diff := cmp.Diff([]string{"hazy", "hoppy"}, merged.Tags); diff != "" {
+				t.Fatalf("\t [ERROR] Should merge the beer tags : %s", diff)
+			}
+			t.Logf("\t [SUCCESS] Should merge the beer tags.")
+
+			var me *beer.MergedError
+			if _, err := core.QueryByID(ctx, duplicate.ID); !errors.As(err, &me) || me.BeerID != canonical.ID {
+				t.Fatalf("\t [ERROR] Should redirect the merged beer to the canonical beer : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should redirect the merged beer to the canonical beer.")
+
+			if _, err := core.QueryByID(ctx, duplicate.ID); !errors.Is(err, beer.ErrNotFound) {
+				t.Fatalf("\t [ERROR] Should NOT find the merged beer : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should NOT find the merged beer.")
+
+			if _, err := core.Merge(ctx, canonical.ID, beer.MergeBeer{DuplicateID: duplicate.ID}, now); !errors.Is(err, beer.ErrNotFound) {
+				t.Fatalf("\t [ERROR] Should NOT be able to merge a beer twice : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should NOT be able to merge a beer twice.")
+
+			if _, err := core.Merge(ctx, other.ID, beer.MergeBeer{DuplicateID: canonical.ID}, now); err != nil {
+				t.Fatalf("\t [ERROR] Should be able to merge the canonical beer : %s", err)
+			}
+
+			if _, err := core.QueryByID(ctx, duplicate.ID); !errors.As(err, &me) || me.BeerID != other.ID {
+				t.Fatalf("\t [ERROR] Should redirect earlier merges to the latest canonical beer : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should redirect earlier merges to the latest canonical beer.")
+		}
+	}
+}
+
+func TestSoftDelete(t *testing.T) {
+	log, db, teardown := dbtest.NewUnit(t, c, "testsoftdelete")
+	t.Cleanup(teardown)
+
+	breweryCore := brewery.NewCore(brewerydb.NewStore(log, db))
+	styleCore := style.NewCore(styledb.NewStore(log, db))
+	core := beer.NewCore(breweryCore, styleCore, local.NewStore(t.TempDir(), "http://localhost/images"), beerdb.NewStore(log, db))
+
+	t.Log("Given the need to delete and restore Beer records.")
+	{
+		t.Logf("\tWhen handling a deleted Beer.")
+		{
+			ctx := context.Background()
+			now := time.Date(2022, 2, 25, 0, 0, 0, 0, time.UTC)
+
+			brw, err := breweryCore.Create(ctx, brewery.NewBrewery{Name: "Test Brewery"}, now)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to add a brewery : %s", err)
+			}
+
+			nb := beer.NewBeer{
+				Name:      "Test Beer",
+				BreweryID: brw.ID,
+				Style:     "American IPA",
+				ABV:       5.5,
+				ShortDesc: "Test Short Description",
+			}
+
+			b, err := core.Create(ctx, nb, false)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to add a beer : %s", err)
+			}
+
+			userID := uuid.NewString()
+			rw, err := core.CreateReview(ctx, userID, b.ID, beer.NewReview{Score: 4, Comment: "Test comment"}, false, now)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to add a review : %s", err)
+			}
+
+			if err := core.Delete(ctx, b.ID); err != nil {
+				t.Fatalf("\t [ERROR] Should be able to delete a beer : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should be able to delete a beer.")
+
+			if _, err := core.QueryByID(ctx, b.ID); !errors.Is(err, beer.ErrNotFound) {
+				t.Fatalf("\t [ERROR] Should NOT find a deleted beer : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should NOT find a deleted beer.")
+
+			beers, err := core.Query(ctx, beer.QueryFilter{}, []order.By{beer.DefaultBeerOrderBy}, 1, 10)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to query beers : %s", err)
+			}
+			if len(beers) != 0 {
+				t.Fatalf("\t [ERROR] Should leave deleted beers out of the list : %+v", beers)
+			}
+			t.Logf("\t [SUCCESS] Should leave deleted beers out of the list.")
+
+			beers, err = core.Query(ctx, beer.QueryFilter{IncludeDeleted: true}, []order.By{beer.DefaultBeerOrderBy}, 1, 10)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to query beers : %s", err)
+			}
+			if len(beers) != 1 || beers[0].DeletedAt == nil {
+				t.Fatalf("\t [ERROR] Should list deleted beers when asked to : %+v", beers)
+			}
+			t.Logf("\t [SUCCESS] Should list deleted beers when asked to.")
+
+			if _, err := core.QueryByIDWithDeleted(ctx, b.ID); err != nil {
+				t.Fatalf("\t [ERROR] Should be able to query a deleted beer by id : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should be able to query a deleted beer by id.")
+
+			if _, err := core.Create(ctx, nb, false); err != nil {
+				t.Fatalf("\t [ERROR] Should be able to add a deleted beer again : %s", err)
+			}
+
+			var de *beer.DuplicateError
+			if _, err := core.Restore(ctx, b.ID); !errors.As(err, &de) {
+				t.Fatalf("\t [ERROR] Should NOT be able to restore a beer added again : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should NOT be able to restore a beer added again.")
+
+			if err := core.Delete(ctx, de.BeerID); err != nil {
+				t.Fatalf("\t [ERROR] Should be able to delete a beer : %s", err)
+			}
+
+			restored, err := core.Restore(ctx, b.ID)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to restore a beer : %s", err)
+			}
+			if restored.DeletedAt != nil || restored.ReviewCount != 1 {
+				t.Fatalf("\t [ERROR] Should restore the beer along with its reviews : %+v", restored)
+			}
+			t.Logf("\t [SUCCESS] Should restore the beer along with its reviews.")
+
+			if _, err := core.Restore(ctx, b.ID); !errors.Is(err, beer.ErrNotFound) {
+				t.Fatalf("\t [ERROR] Should NOT be able to restore a beer not deleted : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should NOT be able to restore a beer not deleted.")
+
+			if err := core.DeleteReview(ctx, userID, b.ID, rw.ID); err != nil {
+				t.Fatalf("\t [ERROR] Should be able to delete a review : %s", err)
+			}
+
+			if _, err := core.CreateReview(ctx, userID, b.ID, beer.NewReview{Score: 2, Comment: "Test comment"}, false, now); err != nil {
+				t.Fatalf("\t [ERROR] Should be able to review a beer again : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should be able to review a beer again.")
+
+			if _, err := core.RestoreReview(ctx, b.ID, rw.ID); !errors.Is(err, beer.ErrDuplicateReview) {
+				t.Fatalf("\t [ERROR] Should NOT be able to restore a review written again : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should NOT be able to restore a review written again.")
+
+			if err := core.Delete(ctx, b.ID); err != nil {
+				t.Fatalf("\t [ERROR] Should be able to delete a beer : %s", err)
+			}
+
+			if err := core.Purge(ctx, time.Now().Add(time.Hour)); err != nil {
+				t.Fatalf("\t [ERROR] Should be able to purge the deleted beers : %s", err)
+			}
+
+			if _, err := core.QueryByIDWithDeleted(ctx, b.ID); !errors.Is(err, beer.ErrNotFound) {
+				t.Fatalf("\t [ERROR] Should NOT find a purged beer : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should NOT find a purged beer.")
 		}
 	}
 }
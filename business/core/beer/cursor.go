@@ -0,0 +1,61 @@
+package beer
+
+import (
+	"strconv"
+
+	"github.com/phbpx/gobeers/business/sys/order"
+)
+
+// cursorTimeFormat is the layout used to store timestamps in a cursor. It
+// matches the microsecond precision of the database timestamps.
+const cursorTimeFormat = "2006-01-02 15:04:05.999999"
+
+// beerCursorValues returns the sort key values and id of a beer for the
+// provided ordering.
+func beerCursorValues(b Beer, orderBy []order.By) ([]string, string) {
+	values := make([]string, len(orderBy))
+	for i, by := range orderBy {
+		switch by.Field {
+		case OrderByID:
+			values[i] = b.ID
+		case OrderByName:
+			values[i] = b.Name
+		case OrderByBrewery:
+			values[i] = b.Brewery
+		case OrderByStyle:
+			values[i] = b.Style
+		case OrderByABV:
+			values[i] = formatFloat(b.ABV)
+		case OrderByScore:
+			values[i] = formatFloat(b.Score)
+		case OrderByCreatedAt:
+			values[i] = b.CreatedAt.UTC().Format(cursorTimeFormat)
+		}
+	}
+	return values, b.ID
+}
+
+// reviewCursorValues returns the sort key values and id of a review for the
+// provided ordering.
+func reviewCursorValues(r Review, orderBy []order.By) ([]string, string) {
+	values := make([]string, len(orderBy))
+	for i, by := range orderBy {
+		switch by.Field {
+		case OrderByID:
+			values[i] = r.ID
+		case OrderByScore:
+			values[i] = formatFloat(r.Score)
+		case OrderByCreatedAt:
+			values[i] = r.CreatedAt.UTC().Format(cursorTimeFormat)
+		case OrderByHelpfulness:
+			values[i] = formatFloat(r.Helpfulness)
+		}
+	}
+	return values, r.ID
+}
+
+// formatFloat formats a float with the shortest representation that reads
+// back to the same float32, which is how the database compares them.
+func formatFloat(f float32) string {
+	return strconv.FormatFloat(float64(f), 'g', -1, 32)
+}
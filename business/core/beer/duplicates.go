@@ -0,0 +1,114 @@
+package beer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/phbpx/gobeers/business/sys/database"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// ErrDuplicateBeer is matched by the DuplicateError returned when a beer with
+// the same normalized name is already made by the brewery.
+var ErrDuplicateBeer = errors.New("beer already exists")
+
+// DuplicateError is returned when creating or renaming a beer conflicts with
+// an existing beer of the same brewery. BeerID is the id of the existing beer.
+type DuplicateError struct {
+	BeerID string
+}
+
+// Error implements the error interface.
+func (de *DuplicateError) Error() string {
+	return fmt.Sprintf("%s: id[%s]", ErrDuplicateBeer, de.BeerID)
+}
+
+// Is makes errors.Is(err, ErrDuplicateBeer) match a DuplicateError.
+func (de *DuplicateError) Is(target error) bool {
+	return target == ErrDuplicateBeer
+}
+
+// nameAbbreviations maps the spelled out style names brewers use in beer
+// names to their usual abbreviation.
+var nameAbbreviations = []struct {
+	words []string
+	abbr  string
+}{
+	{words: []string{"india", "pale", "ale"}, abbr: "ipa"},
+	{words: []string{"india", "pale", "lager"}, abbr: "ipl"},
+	{words: []string{"extra", "special", "bitter"}, abbr: "esb"},
+}
+
+// letterFolds spells out the letters without a decomposed form, which are
+// folded like the unaccent extension the backfill of migration 019 uses.
+var letterFolds = strings.NewReplacer(
+	"ß", "ss", "æ", "ae", "œ", "oe", "ø", "o", "ł", "l",
+	"đ", "d", "ð", "d", "þ", "th", "ı", "i",
+)
+
+// NormalizeName returns the form of a beer name used to detect duplicates:
+// lower case, without accents nor punctuation and with the spelled out style
+// names abbreviated, so "Café India Pale Ale" and "cafe IPA!" are the same
+// beer. The backfill of migration 019 must be kept in line with it.
+func NormalizeName(name string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	if folded, _, err := transform.String(t, name); err == nil {
+		name = folded
+	}
+
+	name = strings.ToLower(strings.ReplaceAll(name, "&", " and "))
+	name = letterFolds.Replace(name)
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+
+	normalized := make([]string, 0, len(words))
+next:
+	for i := 0; i < len(words); i++ {
+		for _, na := range nameAbbreviations {
+			if hasPrefix(words[i:], na.words) {
+				normalized = append(normalized, na.abbr)
+				i += len(na.words) - 1
+				continue next
+			}
+		}
+		normalized = append(normalized, words[i])
+	}
+
+	return strings.Join(normalized, " ")
+}
+
+// hasPrefix reports whether words begins with prefix.
+func hasPrefix(words []string, prefix []string) bool {
+	if len(words) < len(prefix) {
+		return false
+	}
+	for i := range prefix {
+		if words[i] != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// checkDuplicate turns the error of saving the beer into a DuplicateError
+// when it was caused by another beer of the brewery with the same normalized
+// name. The conflict is detected by the database unique index, so concurrent
+// requests can't both save the same beer.
+func (c Core) checkDuplicate(ctx context.Context, beer Beer, err error) error {
+	if beer.Forced || !database.IsIntegrityViolation(err) {
+		return err
+	}
+
+	existing, qErr := c.store.QueryBeerByNormalizedName(ctx, beer.BreweryID, beer.NormalizedName)
+	if qErr != nil || existing.ID == beer.ID {
+		return err
+	}
+
+	return &DuplicateError{BeerID: existing.ID}
+}
@@ -0,0 +1,76 @@
+package beer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phbpx/gobeers/business/sys/validate"
+)
+
+// MaxImageSize is the maximum size in bytes of a beer image.
+const MaxImageSize = 5 << 20
+
+// imageExtensions maps the accepted image content types to the extension of
+// the stored files.
+var imageExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// ImageContentTypes returns the content types accepted for beer images.
+func ImageContentTypes() []string {
+	types := make([]string, 0, len(imageExtensions))
+	for ct := range imageExtensions {
+		types = append(types, ct)
+	}
+	return types
+}
+
+// AddImage stores a new image of the beer. Its return the image along with
+// the URL it's served at, which is also listed in the beer ImageURLs.
+func (c Core) AddImage(ctx context.Context, beerID string, ni NewImage, now time.Time) (Image, error) {
+	if _, err := c.QueryByID(ctx, beerID); err != nil {
+		return Image{}, err
+	}
+
+	ext, exists := imageExtensions[ni.ContentType]
+	switch {
+	case !exists:
+		return Image{}, validate.FieldErrors{{Field: "image", Error: fmt.Sprintf("image type %q is not supported", ni.ContentType)}}
+	case len(ni.Data) == 0:
+		return Image{}, validate.FieldErrors{{Field: "image", Error: "image is a required field"}}
+	case len(ni.Data) > MaxImageSize:
+		return Image{}, validate.FieldErrors{{Field: "image", Error: fmt.Sprintf("image must be a maximum of %d bytes in size", MaxImageSize)}}
+	}
+
+	id := uuid.New().String()
+	key := fmt.Sprintf("beers/%s/%s%s", beerID, id, ext)
+
+	if err := c.images.Put(ctx, key, ni.ContentType, ni.Data); err != nil {
+		return Image{}, fmt.Errorf("putting image blob: %w", err)
+	}
+
+	image := Image{
+		ID:          id,
+		BeerID:      beerID,
+		Key:         key,
+		URL:         c.images.URL(key),
+		ContentType: ni.ContentType,
+		Size:        len(ni.Data),
+		CreatedAt:   now,
+	}
+
+	if err := c.store.AddImage(ctx, image); err != nil {
+		// Don't leave behind a blob nothing references.
+		if dErr := c.images.Delete(ctx, key); dErr != nil {
+			return Image{}, fmt.Errorf("addImage: %w, deleting image blob key[%s]: %s", err, key, dErr)
+		}
+		return Image{}, fmt.Errorf("addImage: %w", err)
+	}
+
+	return image, nil
+}
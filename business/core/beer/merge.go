@@ -0,0 +1,100 @@
+package beer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/phbpx/gobeers/business/sys/database"
+	"github.com/phbpx/gobeers/business/sys/validate"
+)
+
+// ErrBeerMerged is matched by the MergedError returned when querying a beer
+// that was merged into another beer.
+var ErrBeerMerged = errors.New("beer merged into another beer")
+
+// MergedError is returned when the requested beer was merged into another
+// beer. BeerID is the id of the beer it was merged into.
+type MergedError struct {
+	BeerID string
+}
+
+// Error implements the error interface.
+func (me *MergedError) Error() string {
+	return fmt.Sprintf("%s: id[%s]", ErrBeerMerged, me.BeerID)
+}
+
+// Is makes errors.Is(err, ErrBeerMerged) match a MergedError. It also
+// matches ErrNotFound, since there is no beer with the requested id anymore,
+// so callers unaware of merges keep treating it as a missing beer.
+func (me *MergedError) Is(target error) bool {
+	return target == ErrBeerMerged || target == ErrNotFound
+}
+
+// Merge moves the reviews, check-ins, wishlist items, images and tags of the
+// duplicate beer into the canonical beer and removes the duplicate, leaving
+// a redirect to the canonical beer in its place. When a user reviewed both
+// beers only the review of the canonical beer is kept. Its return the
+// canonical Beer with its aggregates recomputed.
+func (c Core) Merge(ctx context.Context, canonicalID string, mb MergeBeer, now time.Time) (Beer, error) {
+	if err := validate.CheckID(canonicalID); err != nil {
+		return Beer{}, ErrInvalidID
+	}
+
+	if err := validate.Check(mb); err != nil {
+		return Beer{}, fmt.Errorf("validating data: %w", err)
+	}
+
+	if mb.DuplicateID == canonicalID {
+		return Beer{}, validate.FieldErrors{{Field: "duplicate_id", Error: "duplicate_id must be another beer"}}
+	}
+
+	// Both beers must exist, a beer already merged can't be merged again.
+	for _, id := range []string{canonicalID, mb.DuplicateID} {
+		if _, err := c.QueryByID(ctx, id); err != nil {
+			return Beer{}, err
+		}
+	}
+
+	var canonical Beer
+	tran := func(s Storer) error {
+		if err := s.MergeBeers(ctx, mb.DuplicateID, canonicalID, now); err != nil {
+			return fmt.Errorf("mergeBeers: %w", err)
+		}
+		if err := s.UpdateBeerStats(ctx, canonicalID); err != nil {
+			return fmt.Errorf("updateBeerStats: %w", err)
+		}
+
+		merged, err := s.QueryBeerByID(ctx, canonicalID)
+		if err != nil {
+			return fmt.Errorf("queryBeerByID: %w", err)
+		}
+		canonical = merged
+
+		return nil
+	}
+
+	if err := c.store.WithinTran(ctx, tran); err != nil {
+		if database.IsNoRowError(err) {
+			return Beer{}, ErrNotFound
+		}
+		return Beer{}, fmt.Errorf("tran: %w", err)
+	}
+
+	return canonical, nil
+}
+
+// checkMerged returns a MergedError when the missing beer was merged into
+// another beer, ErrNotFound otherwise.
+func (c Core) checkMerged(ctx context.Context, beerID string) error {
+	canonicalID, err := c.store.QueryRedirect(ctx, beerID)
+	if err != nil {
+		if database.IsNoRowError(err) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("queryRedirect: %w", err)
+	}
+
+	return &MergedError{BeerID: canonicalID}
+}
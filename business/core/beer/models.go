@@ -1,41 +1,346 @@
 package beer
 
-import "time"
+import (
+	"time"
+
+	"github.com/phbpx/gobeers/business/sys/order"
+)
+
+// Set of fields that the results can be ordered by.
+const (
+	OrderByID          = "id"
+	OrderByName        = "name"
+	OrderByBrewery     = "brewery"
+	OrderByStyle       = "style"
+	OrderByABV         = "abv"
+	OrderByScore       = "score"
+	OrderByCreatedAt   = "created_at"
+	OrderByHelpfulness = "helpfulness"
+)
+
+// DefaultBeerOrderBy is the ordering used for beers when none is provided.
+var DefaultBeerOrderBy = order.NewBy(OrderByName, order.ASC)
+
+// DefaultReviewOrderBy is the ordering used for reviews when none is provided.
+var DefaultReviewOrderBy = order.NewBy(OrderByCreatedAt, order.DESC)
+
+// beerOrderByFields is the whitelist of fields beers can be ordered by.
+var beerOrderByFields = map[string]bool{
+	OrderByID:        true,
+	OrderByName:      true,
+	OrderByBrewery:   true,
+	OrderByStyle:     true,
+	OrderByABV:       true,
+	OrderByScore:     true,
+	OrderByCreatedAt: true,
+}
+
+// reviewOrderByFields is the whitelist of fields reviews can be ordered by.
+var reviewOrderByFields = map[string]bool{
+	OrderByID:          true,
+	OrderByScore:       true,
+	OrderByCreatedAt:   true,
+	OrderByHelpfulness: true,
+}
 
 // NewBeer represents a new beer to be added to the system.
 type NewBeer struct {
-	Name      string  `json:"name" validate:"required"`
-	Brewery   string  `json:"brewery" validate:"required"`
-	Style     string  `json:"style" validate:"required"`
-	ABV       float32 `json:"abv" validate:"required"`
-	ShortDesc string  `json:"short_desc" validate:"required"`
+	Name      string   `json:"name" validate:"required"`
+	BreweryID string   `json:"brewery_id" validate:"required,uuid"`
+	Style     string   `json:"style" validate:"required"`
+	ABV       float32  `json:"abv" validate:"required"`
+	ShortDesc string   `json:"short_desc" validate:"required"`
+	Tags      []string `json:"tags" validate:"max=20,dive,required,max=50"`
 }
 
-// Beer defines the properties of a beer.
+// UpdateBeer defines what information may be provided to modify an existing
+// beer. All fields are optional so clients can send just the fields they want
+// changed. It uses pointer fields so we can differentiate between a field that
+// was not provided and a field that was provided as explicitly blank.
+type UpdateBeer struct {
+	Name      *string   `json:"name" validate:"omitempty,min=1"`
+	BreweryID *string   `json:"brewery_id" validate:"omitempty,uuid"`
+	Style     *string   `json:"style" validate:"omitempty,min=1"`
+	ABV       *float32  `json:"abv" validate:"omitempty,gt=0"`
+	ShortDesc *string   `json:"short_desc" validate:"omitempty,min=1"`
+	Tags      *[]string `json:"tags" validate:"omitempty,max=20,dive,required,max=50"`
+}
+
+// Beer defines the properties of a beer. Brewery is the name of the brewery
+// referenced by BreweryID. Score, ReviewCount and LastReviewedAt are
+// aggregates maintained from the beer reviews, and CheckinCount is the number
+// of times users checked in the beer. ImageURLs lists the beer images, the
+// oldest first, and Tags the names of the beer tags in alphabetical order.
+// NormalizedName identifies duplicates of the beer within its brewery, and
+// Forced is set for the beers added anyway, which are left out of the
+// uniqueness check. DeletedAt is only set for deleted beers, which are kept
+// until they are purged.
 type Beer struct {
-	ID        string    `json:"id"`
-	Name      string    `json:"name"`
-	Brewery   string    `json:"brewery"`
-	Style     string    `json:"style"`
-	ABV       float32   `json:"abv"`
-	ShortDesc string    `json:"short_desc"`
-	Score     float32   `json:"score"`
-	CreatedAt time.Time `json:"created_at"`
+	ID             string     `json:"id"`
+	Name           string     `json:"name"`
+	BreweryID      string     `json:"brewery_id"`
+	Brewery        string     `json:"brewery"`
+	Style          string     `json:"style"`
+	ABV            float32    `json:"abv"`
+	ShortDesc      string     `json:"short_desc"`
+	Score          float32    `json:"score"`
+	ReviewCount    int        `json:"review_count"`
+	LastReviewedAt *time.Time `json:"last_reviewed_at,omitempty"`
+	CheckinCount   int        `json:"checkin_count"`
+	ImageURLs      []string   `json:"image_urls"`
+	Tags           []string   `json:"tags"`
+	NormalizedName string     `json:"-"`
+	Forced         bool       `json:"-"`
+	CreatedAt      time.Time  `json:"created_at"`
+	DeletedAt      *time.Time `json:"deleted_at,omitempty"`
+}
+
+// MergeBeer contains the information needed to merge a duplicate beer into
+// a canonical beer.
+type MergeBeer struct {
+	DuplicateID string `json:"duplicate_id" validate:"required,uuid"`
+}
+
+// NewImage is an image uploaded for a beer. ContentType must be sniffed from
+// the data rather than trusted from the client.
+type NewImage struct {
+	ContentType string
+	Data        []byte
 }
 
-// NewReview defines the input parameters for creating a new review.
+// Image is a stored beer image. Key locates the image in the blob storage.
+type Image struct {
+	ID          string    `json:"id"`
+	BeerID      string    `json:"beer_id"`
+	Key         string    `json:"-"`
+	URL         string    `json:"url"`
+	ContentType string    `json:"content_type"`
+	Size        int       `json:"size"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// SearchResult is a beer matched by a full text search. The snippet holds
+// the matching text with the matched words wrapped in <mark> tags.
+type SearchResult struct {
+	Beer
+	Rank    float32 `json:"rank"`
+	Snippet string  `json:"snippet"`
+}
+
+// SimilarBeer is a beer ranked by how close its attributes are to another
+// beer. Similarity goes from 0 for unrelated beers to 1 for beers that share
+// every attribute.
+type SimilarBeer struct {
+	Beer
+	Similarity float32 `json:"similarity"`
+}
+
+// SimilarityWeights sets how much every attribute counts when comparing two
+// beers. The weights add up to 1. ABVRange is the ABV difference from which
+// two beers are no longer considered similar in strength.
+type SimilarityWeights struct {
+	Style    float32
+	Keywords float32
+	ABV      float32
+	Brewery  float32
+	ABVRange float32
+}
+
+// DefaultSimilarityWeights favors the style and the description, which tell
+// the most about how a beer tastes. Beers in related styles, as in sharing a
+// parent in the style taxonomy, get half the style weight.
+var DefaultSimilarityWeights = SimilarityWeights{
+	Style:    0.4,
+	Keywords: 0.3,
+	ABV:      0.2,
+	Brewery:  0.1,
+	ABVRange: 3,
+}
+
+// TrendingBeer is a beer ranked by how fast it's being reviewed lately
+// compared to how it was reviewed before. RecentReviews is the number of
+// reviews within the trending window.
+type TrendingBeer struct {
+	Beer
+	TrendScore    float32 `json:"trend_score"`
+	RecentReviews int     `json:"recent_reviews"`
+}
+
+// TopBeer is a beer ranked by its Bayesian rating: its average score pulled
+// towards the mean score of all beers, the more the fewer reviews it has.
+type TopBeer struct {
+	Beer
+	Rating float32 `json:"rating"`
+}
+
+// Set of kinds of typeahead suggestions.
+const (
+	SuggestionBeer    = "beer"
+	SuggestionBrewery = "brewery"
+)
+
+// Suggestion is a beer name or brewery matching a typeahead prefix. BeerID
+// is only set for beer suggestions.
+type Suggestion struct {
+	Text   string  `json:"text"`
+	Kind   string  `json:"kind"`
+	BeerID string  `json:"beer_id,omitempty"`
+	Score  float32 `json:"score"`
+}
+
+// NewReview defines the input parameters for creating a new review. The
+// author of the review is the authenticated user, never the payload. When a
+// scoresheet is provided the score is derived from it.
 type NewReview struct {
-	UserID  string  `json:"user_id" validate:"required,uuid"`
-	Score   float32 `json:"score" validate:"required"`
-	Comment string  `json:"comment" validate:"required"`
+	Score      float32     `json:"score" validate:"required_without=Scoresheet"`
+	Comment    string      `json:"comment" validate:"required"`
+	Scoresheet *Scoresheet `json:"scoresheet"`
+}
+
+// UpdateReview defines what information may be provided to modify an existing
+// review. All fields are optional so clients can send just the fields they
+// want changed. Providing a scoresheet replaces the score, while providing
+// only a score drops the scoresheet the previous score was derived from.
+type UpdateReview struct {
+	Score      *float32    `json:"score" validate:"omitempty,gt=0"`
+	Comment    *string     `json:"comment" validate:"omitempty,min=1"`
+	Scoresheet *Scoresheet `json:"scoresheet"`
+}
+
+// Scoresheet is a structured tasting evaluation following the BJCP
+// scoresheet, each section scored up to its maximum points for a total of
+// up to 50 points.
+type Scoresheet struct {
+	Aroma      float32 `json:"aroma" validate:"gte=0,lte=12"`
+	Appearance float32 `json:"appearance" validate:"gte=0,lte=3"`
+	Flavor     float32 `json:"flavor" validate:"gte=0,lte=20"`
+	Mouthfeel  float32 `json:"mouthfeel" validate:"gte=0,lte=5"`
+	Overall    float32 `json:"overall" validate:"gte=0,lte=10"`
+}
+
+// Total returns the sum of the scoresheet sections.
+func (s Scoresheet) Total() float32 {
+	return s.Aroma + s.Appearance + s.Flavor + s.Mouthfeel + s.Overall
 }
 
-// Review defines the properties of a review.
+// Score converts the scoresheet total to the five point scale of review
+// scores.
+func (s Scoresheet) Score() float32 {
+	return s.Total() / 10
+}
+
+// Set of moderation statuses of a review. Only published reviews are shown
+// to readers and counted in the beer aggregates.
+const (
+	ReviewPending   = "pending"
+	ReviewPublished = "published"
+	ReviewRejected  = "rejected"
+	ReviewHidden    = "hidden"
+)
+
+// Review defines the properties of a review. ReportCount is the number of
+// reports received since the review was last approved. It's left out of the
+// JSON along with the moderation details, which only moderators get to see.
+// Helpfulness is the lower bound of the Wilson score interval of the helpful
+// votes, used to rank the most helpful reviews first. DeletedAt is only set
+// for deleted reviews, which are kept until they are purged.
 type Review struct {
+	ID               string      `json:"id"`
+	BeerID           string      `json:"beer_id"`
+	UserID           string      `json:"user_id"`
+	Score            float32     `json:"score"`
+	Comment          string      `json:"comment"`
+	Status           string      `json:"status"`
+	ReportCount      int         `json:"-"`
+	HelpfulCount     int         `json:"helpful_count"`
+	UnhelpfulCount   int         `json:"unhelpful_count"`
+	Helpfulness      float32     `json:"helpfulness"`
+	Scoresheet       *Scoresheet `json:"scoresheet,omitempty"`
+	ModerationReason string      `json:"-"`
+	ModeratedBy      string      `json:"-"`
+	ModeratedAt      *time.Time  `json:"-"`
+	CreatedAt        time.Time   `json:"created_at"`
+	DeletedAt        *time.Time  `json:"deleted_at,omitempty"`
+}
+
+// Stats holds the aggregates of a beer. Scoresheet holds the average of every
+// scoresheet section across the published reviews that include one.
+type Stats struct {
+	BeerID       string          `json:"beer_id"`
+	Score        float32         `json:"score"`
+	ReviewCount  int             `json:"review_count"`
+	CheckinCount int             `json:"checkin_count"`
+	Scoresheet   ScoresheetStats `json:"scoresheet"`
+}
+
+// ScoresheetStats holds the per section averages of the scoresheets of a
+// beer.
+type ScoresheetStats struct {
+	Count      int     `json:"count"`
+	Aroma      float32 `json:"aroma"`
+	Appearance float32 `json:"appearance"`
+	Flavor     float32 `json:"flavor"`
+	Mouthfeel  float32 `json:"mouthfeel"`
+	Overall    float32 `json:"overall"`
+	Total      float32 `json:"total"`
+}
+
+// NewReport contains information needed to report a review.
+type NewReport struct {
+	Reason string `json:"reason" validate:"required,max=500"`
+}
+
+// Report defines the properties of a user report on a review.
+type Report struct {
 	ID        string    `json:"id"`
-	BeerID    string    `json:"beer_id"`
+	ReviewID  string    `json:"review_id"`
 	UserID    string    `json:"user_id"`
-	Score     float32   `json:"score"`
-	Comment   string    `json:"comment"`
+	Reason    string    `json:"reason"`
 	CreatedAt time.Time `json:"created_at"`
 }
+
+// NewVote contains information needed to vote on the helpfulness of a
+// review.
+type NewVote struct {
+	Helpful *bool `json:"helpful" validate:"required"`
+}
+
+// Vote defines the helpfulness vote of a user on a review.
+type Vote struct {
+	ReviewID  string    `json:"review_id"`
+	UserID    string    `json:"user_id"`
+	Helpful   bool      `json:"helpful"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ModerationDecision contains the information provided by a moderator when
+// approving or rejecting a review. The reason is optional and only shown to
+// moderators.
+type ModerationDecision struct {
+	Reason string `json:"reason" validate:"max=500"`
+}
+
+// Set of ways the tags of a QueryFilter are matched.
+const (
+	TagMatchAny = "any"
+	TagMatchAll = "all"
+)
+
+// QueryFilter holds the available fields a query can be filtered on. Every
+// field is optional and only the ones that are set are applied. Tags matches
+// the beers with any of the tags, or with all of them when TagMatch is
+// TagMatchAll. Deleted beers are left out unless IncludeDeleted is set.
+type QueryFilter struct {
+	Style          *string    `json:"style" validate:"omitempty,min=1"`
+	Brewery        *string    `json:"brewery" validate:"omitempty,min=1"`
+	BreweryID      *string    `json:"brewery_id" validate:"omitempty,uuid"`
+	Name           *string    `json:"name" validate:"omitempty,min=1"`
+	MinABV         *float32   `json:"min_abv" validate:"omitempty,gte=0"`
+	MaxABV         *float32   `json:"max_abv" validate:"omitempty,gte=0"`
+	CreatedAfter   *time.Time `json:"created_after"`
+	CreatedBefore  *time.Time `json:"created_before"`
+	MinScore       *float32   `json:"min_score" validate:"omitempty,gte=0"`
+	Tags           []string   `json:"tags" validate:"omitempty,max=20,dive,required,max=50"`
+	TagMatch       string     `json:"tag_match" validate:"omitempty,oneof=any all"`
+	IncludeDeleted bool       `json:"include_deleted"`
+}
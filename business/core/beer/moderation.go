@@ -0,0 +1,195 @@
+package beer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phbpx/gobeers/business/sys/database"
+	"github.com/phbpx/gobeers/business/sys/validate"
+)
+
+// ReportHideThreshold is the number of reports that automatically hides a
+// published review until a moderator looks at it.
+const ReportHideThreshold = 3
+
+// ReportReview adds a user report on a published review. Once the review
+// reaches ReportHideThreshold reports it's hidden and shows up in the
+// moderation queue. Users can't report their own reviews nor report the same
+// review twice.
+func (c Core) ReportReview(ctx context.Context, userID string, reviewID string, nr NewReport, now time.Time) (Report, error) {
+	if err := validate.CheckID(reviewID); err != nil {
+		return Report{}, ErrInvalidID
+	}
+
+	if err := validate.CheckID(userID); err != nil {
+		return Report{}, ErrForbidden
+	}
+
+	if err := validate.Check(nr); err != nil {
+		return Report{}, fmt.Errorf("validating data: %w", err)
+	}
+
+	review, err := c.queryReview(ctx, reviewID)
+	if err != nil {
+		return Report{}, err
+	}
+
+	if review.Status != ReviewPublished {
+		return Report{}, ErrReviewNotFound
+	}
+
+	if review.UserID == userID {
+		return Report{}, ErrForbidden
+	}
+
+	report := Report{
+		ID:        uuid.New().String(),
+		ReviewID:  review.ID,
+		UserID:    userID,
+		Reason:    nr.Reason,
+		CreatedAt: now,
+	}
+
+	tran := func(s Storer) error {
+		if err := s.AddReport(ctx, report); err != nil {
+			return fmt.Errorf("addReport: %w", err)
+		}
+
+		count, err := s.IncrementReportCount(ctx, review.ID)
+		if err != nil {
+			return fmt.Errorf("incrementReportCount: %w", err)
+		}
+
+		if count < ReportHideThreshold {
+			return nil
+		}
+
+		// The review row is locked by the increment, so this read sees the
+		// latest moderation status.
+		current, err := s.QueryReviewByID(ctx, review.ID)
+		if err != nil {
+			return fmt.Errorf("queryReviewByID: %w", err)
+		}
+
+		if current.Status != ReviewPublished {
+			return nil
+		}
+
+		current.Status = ReviewHidden
+		current.ModerationReason = fmt.Sprintf("hidden after %d reports", count)
+
+		if err := s.UpdateReview(ctx, current); err != nil {
+			return fmt.Errorf("updateReview: %w", err)
+		}
+		if err := s.UpdateBeerStats(ctx, current.BeerID); err != nil {
+			return fmt.Errorf("updateBeerStats: %w", err)
+		}
+		return nil
+	}
+
+	if err := c.store.WithinTran(ctx, tran); err != nil {
+		switch {
+		case database.IsUniqueViolation(err, uniqueReportAuthor):
+			return Report{}, ErrDuplicateReport
+		case database.IsForeignKeyViolation(err), database.IsNoRowError(err):
+			return Report{}, ErrReviewNotFound
+		}
+		return Report{}, fmt.Errorf("tran: %w", err)
+	}
+
+	return report, nil
+}
+
+// QueryModerationQueue gets the pending and hidden reviews waiting for a
+// moderator, the most reported first.
+func (c Core) QueryModerationQueue(ctx context.Context, page int, size int) ([]Review, error) {
+	reviews, err := c.store.QueryModerationQueue(ctx, page, size)
+	if err != nil {
+		return nil, fmt.Errorf("queryModerationQueue: %w", err)
+	}
+
+	return reviews, nil
+}
+
+// ApproveReview publishes a review and clears its report count.
+func (c Core) ApproveReview(ctx context.Context, moderatorID string, reviewID string, md ModerationDecision, now time.Time) (Review, error) {
+	return c.moderate(ctx, moderatorID, reviewID, ReviewPublished, md, now)
+}
+
+// RejectReview takes a review down for good.
+func (c Core) RejectReview(ctx context.Context, moderatorID string, reviewID string, md ModerationDecision, now time.Time) (Review, error) {
+	return c.moderate(ctx, moderatorID, reviewID, ReviewRejected, md, now)
+}
+
+// moderate records a moderator decision on a review and refreshes the
+// aggregates of the reviewed beer.
+func (c Core) moderate(ctx context.Context, moderatorID string, reviewID string, status string, md ModerationDecision, now time.Time) (Review, error) {
+	if err := validate.CheckID(reviewID); err != nil {
+		return Review{}, ErrInvalidID
+	}
+
+	if err := validate.CheckID(moderatorID); err != nil {
+		return Review{}, ErrForbidden
+	}
+
+	if err := validate.Check(md); err != nil {
+		return Review{}, fmt.Errorf("validating data: %w", err)
+	}
+
+	decision := Review{
+		ID:               reviewID,
+		Status:           status,
+		ModerationReason: md.Reason,
+		ModeratedBy:      moderatorID,
+		ModeratedAt:      &now,
+	}
+
+	// Only the moderation fields are written, the review is read back under
+	// the row lock so concurrent reports and votes aren't lost.
+	var review Review
+	tran := func(s Storer) error {
+		if err := s.ModerateReview(ctx, decision); err != nil {
+			return fmt.Errorf("moderateReview: %w", err)
+		}
+		if status == ReviewPublished {
+			if err := s.ResetReportCount(ctx, reviewID); err != nil {
+				return fmt.Errorf("resetReportCount: %w", err)
+			}
+		}
+
+		moderated, err := s.QueryReviewByID(ctx, reviewID)
+		if err != nil {
+			return fmt.Errorf("queryReviewByID: %w", err)
+		}
+		review = moderated
+
+		if err := s.UpdateBeerStats(ctx, review.BeerID); err != nil {
+			return fmt.Errorf("updateBeerStats: %w", err)
+		}
+		return nil
+	}
+
+	if err := c.store.WithinTran(ctx, tran); err != nil {
+		if database.IsNoRowError(err) {
+			return Review{}, ErrReviewNotFound
+		}
+		return Review{}, fmt.Errorf("tran: %w", err)
+	}
+
+	return review, nil
+}
+
+// queryReview gets a review by its id.
+func (c Core) queryReview(ctx context.Context, reviewID string) (Review, error) {
+	review, err := c.store.QueryReviewByID(ctx, reviewID)
+	if err != nil {
+		if database.IsNoRowError(err) {
+			return Review{}, ErrReviewNotFound
+		}
+		return Review{}, fmt.Errorf("queryReviewByID: %w", err)
+	}
+
+	return review, nil
+}
@@ -0,0 +1,135 @@
+package beer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/phbpx/gobeers/business/sys/database"
+	"github.com/phbpx/gobeers/business/sys/validate"
+)
+
+// QueryByIDWithDeleted gets the specified beer from the database, even if it
+// was deleted.
+func (c Core) QueryByIDWithDeleted(ctx context.Context, id string) (Beer, error) {
+	if err := validate.CheckID(id); err != nil {
+		return Beer{}, ErrInvalidID
+	}
+
+	beer, err := c.store.QueryBeerByIDWithDeleted(ctx, id)
+	if err != nil {
+		if database.IsNoRowError(err) {
+			return Beer{}, c.checkMerged(ctx, id)
+		}
+		return Beer{}, fmt.Errorf("queryBeerByIDWithDeleted: %w", err)
+	}
+
+	return beer, nil
+}
+
+// Restore brings back a deleted beer along with its reviews. A DuplicateError
+// is returned when the brewery added the same beer again in the meantime.
+func (c Core) Restore(ctx context.Context, beerID string) (Beer, error) {
+	beer, err := c.QueryByIDWithDeleted(ctx, beerID)
+	if err != nil {
+		return Beer{}, err
+	}
+
+	if beer.DeletedAt == nil {
+		return Beer{}, ErrNotFound
+	}
+
+	if err := c.store.RestoreBeer(ctx, beerID); err != nil {
+		if database.IsNoRowError(err) {
+			return Beer{}, ErrNotFound
+		}
+		return Beer{}, fmt.Errorf("restoreBeer: %w", c.checkDuplicate(ctx, beer, err))
+	}
+
+	beer.DeletedAt = nil
+
+	return beer, nil
+}
+
+// RestoreReview brings back a deleted review of the beer. It fails with
+// ErrDuplicateReview when its author reviewed the beer again in the meantime.
+func (c Core) RestoreReview(ctx context.Context, beerID string, reviewID string) (Review, error) {
+	if err := validate.CheckID(beerID); err != nil {
+		return Review{}, ErrInvalidID
+	}
+
+	if err := validate.CheckID(reviewID); err != nil {
+		return Review{}, ErrInvalidID
+	}
+
+	if _, err := c.QueryByID(ctx, beerID); err != nil {
+		return Review{}, err
+	}
+
+	var review Review
+	tran := func(s Storer) error {
+		if err := s.RestoreReview(ctx, reviewID); err != nil {
+			return fmt.Errorf("restoreReview: %w", err)
+		}
+
+		restored, err := s.QueryReviewByID(ctx, reviewID)
+		if err != nil {
+			return fmt.Errorf("queryReviewByID: %w", err)
+		}
+		if restored.BeerID != beerID {
+			return ErrReviewNotFound
+		}
+		review = restored
+
+		if err := s.UpdateBeerStats(ctx, beerID); err != nil {
+			return fmt.Errorf("updateBeerStats: %w", err)
+		}
+		return nil
+	}
+
+	if err := c.store.WithinTran(ctx, tran); err != nil {
+		switch {
+		case database.IsNoRowError(err), errors.Is(err, ErrReviewNotFound):
+			return Review{}, ErrReviewNotFound
+		case database.IsUniqueViolation(err, uniqueReviewAuthor):
+			return Review{}, ErrDuplicateReview
+		}
+		return Review{}, fmt.Errorf("tran: %w", err)
+	}
+
+	return review, nil
+}
+
+// Purge hard deletes the beers and reviews deleted before the provided time,
+// removing the blobs of the images of the purged beers from the storage. A
+// blob that can't be removed doesn't stop the others, the error returned
+// lists the keys of those left behind.
+func (c Core) Purge(ctx context.Context, before time.Time) error {
+	if err := c.store.PurgeReviews(ctx, before); err != nil {
+		return fmt.Errorf("purgeReviews: %w", err)
+	}
+
+	keys, err := c.store.PurgeBeers(ctx, before)
+	if err != nil {
+		return fmt.Errorf("purgeBeers: %w", err)
+	}
+
+	// The image records are gone along with the beers, so every blob is
+	// attempted and the ones left behind are reported by key.
+	var failed []string
+	var lastErr error
+	for _, key := range keys {
+		if err := c.images.Delete(ctx, key); err != nil {
+			failed = append(failed, key)
+			lastErr = err
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("deleting %d image blobs, keys[%s]: %w", len(failed), strings.Join(failed, ","), lastErr)
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+// Package beercache contains beer related CRUD functionality with caching.
+package beercache
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/phbpx/gobeers/business/core/beer"
+	"go.uber.org/zap"
+)
+
+// Store manages the set of APIs for beer data and caching. Every call not
+// cached goes straight to the wrapped storer, including the calls made
+// within a transaction.
+type Store struct {
+	beer.Storer
+	log      *zap.SugaredLogger
+	ttl      time.Duration
+	trending *cache
+}
+
+// NewStore constructs the api for data and caching access. Results are kept
+// for the ttl, a ttl of zero disables the cache.
+func NewStore(log *zap.SugaredLogger, storer beer.Storer, ttl time.Duration) Store {
+	return Store{
+		Storer:   storer,
+		log:      log,
+		ttl:      ttl,
+		trending: &cache{entries: make(map[string]entry)},
+	}
+}
+
+// QueryTrendingBeers returns the trending beers computed within the ttl for
+// the same window and limit, if any, otherwise they are computed again.
+func (s Store) QueryTrendingBeers(ctx context.Context, window time.Duration, limit int, now time.Time) ([]beer.TrendingBeer, error) {
+	if s.ttl <= 0 {
+		return s.Storer.QueryTrendingBeers(ctx, window, limit, now)
+	}
+
+	key := fmt.Sprintf("%s:%d", window, limit)
+	if trending, exists := s.trending.get(key, now); exists {
+		return trending, nil
+	}
+
+	trending, err := s.Storer.QueryTrendingBeers(ctx, window, limit, now)
+	if err != nil {
+		return nil, err
+	}
+
+	s.trending.set(key, trending, now, now.Add(s.ttl))
+
+	return trending, nil
+}
+
+// =============================================================================
+
+// entry is a cached result along with the time it expires.
+type entry struct {
+	trending  []beer.TrendingBeer
+	expiresAt time.Time
+}
+
+// cache holds results safe for concurrent use.
+type cache struct {
+	mu      sync.RWMutex
+	entries map[string]entry
+}
+
+// get returns the result cached under the key if it hasn't expired yet.
+func (c *cache) get(key string, now time.Time) ([]beer.TrendingBeer, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	e, exists := c.entries[key]
+	if !exists || !now.Before(e.expiresAt) {
+		return nil, false
+	}
+
+	return e.trending, true
+}
+
+// set caches the result under the key, dropping the results expired by now.
+func (c *cache) set(key string, trending []beer.TrendingBeer, now time.Time, expiresAt time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for k, e := range c.entries {
+		if !now.Before(e.expiresAt) {
+			delete(c.entries, k)
+		}
+	}
+
+	c.entries[key] = entry{trending: trending, expiresAt: expiresAt}
+}
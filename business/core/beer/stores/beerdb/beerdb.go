@@ -3,17 +3,24 @@ package beerdb
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
+	"strings"
+	"time"
+	"unicode"
 
 	"github.com/phbpx/gobeers/business/core/beer"
+	"github.com/phbpx/gobeers/business/sys/cursor"
+	"github.com/phbpx/gobeers/business/sys/order"
 	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/pgdialect"
 	"go.uber.org/zap"
 )
 
 // Store manages the set of APIs for beer access.
 type Store struct {
 	log *zap.SugaredLogger
-	db  *bun.DB
+	db  bun.IDB
 }
 
 // NewStore constructs a data for api access.
@@ -24,6 +31,13 @@ func NewStore(log *zap.SugaredLogger, db *bun.DB) Store {
 	}
 }
 
+// WithinTran runs passed function and do commit/rollback at the end.
+func (s Store) WithinTran(ctx context.Context, fn func(beer.Storer) error) error {
+	return s.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		return fn(Store{log: s.log, db: &tx})
+	})
+}
+
 // AddBeer adds a new beer to the database.
 func (s Store) AddBeer(ctx context.Context, b beer.Beer) error {
 	dbBeer := toDBBeer(b)
@@ -39,9 +53,24 @@ func (s Store) AddBeer(ctx context.Context, b beer.Beer) error {
 func (s Store) QueryBeerByID(ctx context.Context, beerID string) (beer.Beer, error) {
 	var b dbBeer
 
-	query := s.db.NewSelect().
-		Model(&b).
-		Where("id = ?", beerID)
+	query := s.selectBeers(&b).
+		Where("b.id = ?", beerID)
+
+	if err := query.Scan(ctx); err != nil {
+		return beer.Beer{}, fmt.Errorf("querying beer by [id=%s]: %w", beerID, err)
+	}
+
+	return toBeer(b), nil
+}
+
+// QueryBeerByIDWithDeleted retrieves a beer by its id, even if it was
+// deleted.
+func (s Store) QueryBeerByIDWithDeleted(ctx context.Context, beerID string) (beer.Beer, error) {
+	var b dbBeer
+
+	query := s.selectBeers(&b).
+		WhereAllWithDeleted().
+		Where("b.id = ?", beerID)
 
 	if err := query.Scan(ctx); err != nil {
 		return beer.Beer{}, fmt.Errorf("querying beer by [id=%s]: %w", beerID, err)
@@ -50,22 +79,523 @@ func (s Store) QueryBeerByID(ctx context.Context, beerID string) (beer.Beer, err
 	return toBeer(b), nil
 }
 
-// QueryBeers retrieves a list of existing beers.
-func (s Store) QueryBeers(ctx context.Context, page, size int) ([]beer.Beer, error) {
+// QueryBeerByNormalizedName retrieves the beer of the brewery with the
+// normalized name, ignoring the beers forced as duplicates.
+func (s Store) QueryBeerByNormalizedName(ctx context.Context, breweryID string, normalizedName string) (beer.Beer, error) {
+	var b dbBeer
+
+	query := s.selectBeers(&b).
+		Where("b.brewery_id = ?", breweryID).
+		Where("b.normalized_name = ?", normalizedName).
+		Where("NOT b.forced")
+
+	if err := query.Scan(ctx); err != nil {
+		return beer.Beer{}, fmt.Errorf("querying beer by [brewery_id=%s, normalized_name=%s]: %w", breweryID, normalizedName, err)
+	}
+
+	return toBeer(b), nil
+}
+
+// QueryBeersByIDs retrieves the beers with the provided ids.
+func (s Store) QueryBeersByIDs(ctx context.Context, beerIDs []string) ([]beer.Beer, error) {
 	var beers []dbBeer
 
-	query := s.db.NewSelect().
-		Model(&beers).
+	query := s.selectBeers(&beers).
+		Where("b.id IN (?)", bun.In(beerIDs))
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, fmt.Errorf("querying beers by ids: %w", err)
+	}
+
+	return toBeers(beers), nil
+}
+
+// UpdateBeer replaces a beer document in the database.
+func (s Store) UpdateBeer(ctx context.Context, b beer.Beer) error {
+	dbBeer := toDBBeer(b)
+
+	if _, err := s.db.NewUpdate().Model(&dbBeer).WherePK().Exec(ctx); err != nil {
+		return fmt.Errorf("updating beer [id=%s]: %w", b.ID, err)
+	}
+
+	return nil
+}
+
+// DeleteBeer soft deletes a beer, it's kept until purged. It returns
+// sql.ErrNoRows when there is no beer with the provided id.
+func (s Store) DeleteBeer(ctx context.Context, beerID string) error {
+	res, err := s.db.NewDelete().
+		Model((*dbBeer)(nil)).
+		Where("id = ?", beerID).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("deleting beer [id=%s]: %w", beerID, err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("deleting beer [id=%s]: %w", beerID, err)
+	}
+	if n == 0 {
+		return fmt.Errorf("deleting beer [id=%s]: %w", beerID, sql.ErrNoRows)
+	}
+
+	return nil
+}
+
+// RestoreBeer restores a deleted beer. It returns sql.ErrNoRows when there
+// is no deleted beer with the provided id.
+func (s Store) RestoreBeer(ctx context.Context, beerID string) error {
+	res, err := s.db.NewUpdate().
+		Model((*dbBeer)(nil)).
+		Set("deleted_at = NULL").
+		Where("id = ?", beerID).
+		WhereDeleted().
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("restoring beer [id=%s]: %w", beerID, err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("restoring beer [id=%s]: %w", beerID, err)
+	}
+	if n == 0 {
+		return fmt.Errorf("restoring beer [id=%s]: %w", beerID, sql.ErrNoRows)
+	}
+
+	return nil
+}
+
+// PurgeBeers hard deletes the beers deleted before the time, along with
+// everything recorded for them. Its return the blob keys of the images of
+// the purged beers, which the caller must delete.
+func (s Store) PurgeBeers(ctx context.Context, before time.Time) ([]string, error) {
+	// Every part of the statement sees the same snapshot, so the images are
+	// still there to be read although the delete cascades to them.
+	const q = `
+	WITH purged AS (
+		DELETE FROM beers
+		WHERE deleted_at IS NOT NULL AND deleted_at < ?
+		RETURNING id
+	)
+	SELECT bi.key
+	FROM beer_images AS bi
+	JOIN purged AS p ON p.id = bi.beer_id`
+
+	var keys []string
+
+	if err := s.db.NewRaw(q, before).Scan(ctx, &keys); err != nil {
+		return nil, fmt.Errorf("purging beers [before=%s]: %w", before, err)
+	}
+
+	return keys, nil
+}
+
+// MergeBeers moves everything recorded for the duplicate beer to the
+// canonical beer, deletes the duplicate and redirects it to the canonical
+// beer. It must run within a transaction. It returns sql.ErrNoRows when
+// either beer doesn't exist.
+func (s Store) MergeBeers(ctx context.Context, duplicateID string, canonicalID string, now time.Time) error {
+	var locked []string
+
+	lock := s.db.NewSelect().
+		Model((*dbBeer)(nil)).
+		Column("id").
+		Where("id IN (?)", bun.In([]string{duplicateID, canonicalID})).
+		OrderExpr("id").
+		For("UPDATE")
+
+	if err := lock.Scan(ctx, &locked); err != nil {
+		return fmt.Errorf("locking beers [ids=%s,%s]: %w", duplicateID, canonicalID, err)
+	}
+	if len(locked) != 2 {
+		return fmt.Errorf("locking beers [ids=%s,%s]: %w", duplicateID, canonicalID, sql.ErrNoRows)
+	}
+
+	// Every statement takes the duplicate id as ?0, the canonical id as ?1
+	// and the time of the merge as ?2. Rows that can't be moved because the
+	// canonical beer already has an equivalent one are left behind and go
+	// away with the duplicate, except reviews, which are soft deleted and
+	// moved along with the rest.
+	stmts := []struct {
+		name string
+		q    string
+	}{
+		{"deleting conflicting reviews", `
+		UPDATE reviews AS d
+		SET deleted_at = ?2
+		FROM reviews AS c
+		WHERE d.beer_id = ?0 AND c.beer_id = ?1 AND c.user_id = d.user_id
+			AND d.deleted_at IS NULL AND c.deleted_at IS NULL`},
+		{"moving reviews", `UPDATE reviews SET beer_id = ?1 WHERE beer_id = ?0`},
+		{"moving checkins", `UPDATE checkins SET beer_id = ?1 WHERE beer_id = ?0`},
+		{"moving images", `UPDATE beer_images SET beer_id = ?1 WHERE beer_id = ?0`},
+		{"moving wishlist items", `
+		INSERT INTO wishlist_items (user_id, beer_id, created_at)
+		SELECT user_id, ?1, created_at FROM wishlist_items WHERE beer_id = ?0
+		ON CONFLICT (user_id, beer_id) DO NOTHING`},
+		{"moving tags", `
+		INSERT INTO beer_tags (beer_id, tag_id)
+		SELECT ?1, tag_id FROM beer_tags WHERE beer_id = ?0
+		ON CONFLICT (beer_id, tag_id) DO NOTHING`},
+		{"counting checkins", `
+		INSERT INTO beer_stats (beer_id, checkin_count)
+		SELECT ?1, COUNT(*) FROM checkins WHERE beer_id = ?1
+		ON CONFLICT (beer_id) DO UPDATE SET checkin_count = EXCLUDED.checkin_count`},
+		{"moving redirects", `UPDATE beer_redirects SET beer_id = ?1 WHERE beer_id = ?0`},
+		{"deleting duplicate", `DELETE FROM beers WHERE id = ?0`},
+		{"adding redirect", `INSERT INTO beer_redirects (old_id, beer_id, created_at) VALUES (?0, ?1, ?2)`},
+	}
+
+	for _, stmt := range stmts {
+		if _, err := s.db.ExecContext(ctx, stmt.q, duplicateID, canonicalID, now); err != nil {
+			return fmt.Errorf("merging beers [ids=%s,%s]: %s: %w", duplicateID, canonicalID, stmt.name, err)
+		}
+	}
+
+	return nil
+}
+
+// QueryRedirect retrieves the id of the beer the beer was merged into.
+func (s Store) QueryRedirect(ctx context.Context, beerID string) (string, error) {
+	var canonicalID string
+
+	const q = `SELECT beer_id FROM beer_redirects WHERE old_id = ?`
+
+	if err := s.db.NewRaw(q, beerID).Scan(ctx, &canonicalID); err != nil {
+		return "", fmt.Errorf("querying redirect [id=%s]: %w", beerID, err)
+	}
+
+	return canonicalID, nil
+}
+
+// QueryBeers retrieves a list of existing beers that match the filter.
+func (s Store) QueryBeers(ctx context.Context, filter beer.QueryFilter, orderBy []order.By, page, size int) ([]beer.Beer, error) {
+	var beers []dbBeer
+
+	query := s.selectBeers(&beers).
 		Limit(size).
 		Offset(size * (page - 1))
 
+	applyFilter(query, filter)
+
+	if err := applyOrderBy(query, orderBy, beerOrderByColumns, "b.id"); err != nil {
+		return nil, err
+	}
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, fmt.Errorf("querying beer: %w", err)
+	}
+
+	return toBeers(beers), nil
+}
+
+// QueryBeersByCursor retrieves a list of existing beers that match the filter,
+// positioned by the cursor.
+func (s Store) QueryBeersByCursor(ctx context.Context, filter beer.QueryFilter, cur cursor.Cursor, limit int) ([]beer.Beer, error) {
+	var beers []dbBeer
+
+	query := s.selectBeers(&beers).
+		Limit(limit)
+
+	applyFilter(query, filter)
+
+	if err := applyCursor(query, cur, beerOrderByColumns, "b.id"); err != nil {
+		return nil, err
+	}
+
 	if err := query.Scan(ctx); err != nil {
 		return nil, fmt.Errorf("querying beer: %w", err)
 	}
 
+	if cur.Before {
+		reverse(beers)
+	}
+
 	return toBeers(beers), nil
 }
 
+// SearchBeers runs a full text search over the beer name, brewery and short
+// description. Every word of the query is matched as a prefix and results
+// are ranked by relevance, with the matching words highlighted in a snippet.
+func (s Store) SearchBeers(ctx context.Context, text string, page int, size int) ([]beer.SearchResult, error) {
+	tsQuery := toTSQuery(text)
+	if tsQuery == "" {
+		return nil, nil
+	}
+
+	const headline = `ts_headline('english', b.name || ' - ' || b.brewery || ' - ' || b.short_desc, q.query, 'StartSel=<mark>, StopSel=</mark>, MaxFragments=2')`
+
+	var results []dbSearchResult
+
+	query := s.selectBeers(&results).
+		ColumnExpr("ts_rank(b.search_vector, q.query) AS rank").
+		ColumnExpr(headline+" AS snippet").
+		Join("CROSS JOIN to_tsquery('english', ?) AS q(query)", tsQuery).
+		Where("b.search_vector @@ q.query").
+		OrderExpr("rank DESC").
+		OrderExpr("b.id ASC").
+		Limit(size).
+		Offset(size * (page - 1))
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, fmt.Errorf("searching beers [q=%s]: %w", text, err)
+	}
+
+	return toSearchResults(results), nil
+}
+
+// SuggestBeers returns the beer names and breweries that start with, or are
+// similar to, the prefix. Prefix matches rank first, then the rest by their
+// trigram word similarity so misspelled prefixes still find a match.
+func (s Store) SuggestBeers(ctx context.Context, prefix string, limit int) ([]beer.Suggestion, error) {
+	const q = `
+	(
+		SELECT
+			name AS text,
+			'beer' AS kind,
+			id AS beer_id,
+			GREATEST(word_similarity(?0, name), CASE WHEN name ILIKE ?1 THEN 1 ELSE 0 END) AS score
+		FROM beers
+		WHERE deleted_at IS NULL AND (name ILIKE ?1 OR ?0 <% name)
+		ORDER BY score DESC, name
+		LIMIT ?2
+	)
+	UNION ALL
+	(
+		SELECT DISTINCT ON (LOWER(brewery))
+			brewery AS text,
+			'brewery' AS kind,
+			NULL AS beer_id,
+			GREATEST(word_similarity(?0, brewery), CASE WHEN brewery ILIKE ?1 THEN 1 ELSE 0 END) AS score
+		FROM beers
+		WHERE deleted_at IS NULL AND (brewery ILIKE ?1 OR ?0 <% brewery)
+		ORDER BY LOWER(brewery), score DESC
+	)
+	ORDER BY score DESC, text
+	LIMIT ?2`
+
+	var suggestions []dbSuggestion
+
+	if err := s.db.NewRaw(q, prefix, escapeLike(prefix)+"%", limit).Scan(ctx, &suggestions); err != nil {
+		return nil, fmt.Errorf("suggesting beers [prefix=%s]: %w", prefix, err)
+	}
+
+	return toSuggestions(suggestions), nil
+}
+
+// QuerySimilarBeers ranks the other beers by the weighted sum of how close
+// their attributes are to the ones of the specified beer:
+//
+//   - style: 1 for the same style, 0.5 for styles sharing a parent or
+//     parent and child styles in the taxonomy
+//   - keywords: the Jaccard index of the description keywords
+//   - abv: decreases linearly to 0 at an ABV difference of weights.ABVRange
+//   - brewery: 1 for the same brewery
+//
+// Every beer is scored in a single pass, keeping only the best ones.
+func (s Store) QuerySimilarBeers(ctx context.Context, beerID string, weights beer.SimilarityWeights, limit int) ([]beer.SimilarBeer, error) {
+	const target = `
+	JOIN (
+		SELECT tb.id, tb.style, tb.abv, tb.brewery_id, tb.keywords, ts.id AS style_id, ts.parent_id AS style_parent_id
+		FROM beers AS tb
+		LEFT JOIN styles AS ts ON LOWER(ts.name) = LOWER(tb.style)
+		WHERE tb.id = ? AND tb.deleted_at IS NULL
+	) AS t ON t.id <> b.id`
+
+	const similarity = `
+	(
+		?0 * CASE
+			WHEN LOWER(b.style) = LOWER(t.style) THEN 1
+			WHEN st.parent_id = t.style_parent_id OR st.parent_id = t.style_id OR st.id = t.style_parent_id THEN 0.5
+			ELSE 0
+		END +
+		?1 * COALESCE(kw.common::float / NULLIF(cardinality(b.keywords) + cardinality(t.keywords) - kw.common, 0), 0) +
+		?2 * GREATEST(0, 1 - ABS(b.abv - t.abv) / ?4) +
+		?3 * CASE WHEN b.brewery_id = t.brewery_id THEN 1 ELSE 0 END
+	)::real AS similarity`
+
+	var similar []dbSimilarBeer
+
+	query := s.selectBeers(&similar).
+		ColumnExpr(similarity, weights.Style, weights.Keywords, weights.ABV, weights.Brewery, weights.ABVRange).
+		Join(target, beerID).
+		Join("LEFT JOIN styles AS st ON LOWER(st.name) = LOWER(b.style)").
+		Join("CROSS JOIN LATERAL (SELECT COUNT(*) AS common FROM unnest(b.keywords) AS k WHERE k = ANY(t.keywords)) AS kw").
+		OrderExpr("similarity DESC").
+		OrderExpr("b.id ASC").
+		Limit(limit)
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, fmt.Errorf("querying similar beers [id=%s]: %w", beerID, err)
+	}
+
+	return toSimilarBeers(similar), nil
+}
+
+// baselineWindows is the number of windows before the trending window used
+// as the historical baseline of a beer.
+const baselineWindows = 4
+
+// QueryTrendingBeers ranks the beers reviewed within the window ending now.
+// Every recent review counts as exp(-ln(2) * age / halfLife), with a half
+// life of half the window, and the sum is divided by the number of reviews
+// per window the beer got over the baselineWindows windows before, plus one
+// so new beers don't divide by zero.
+func (s Store) QueryTrendingBeers(ctx context.Context, window time.Duration, limit int, now time.Time) ([]beer.TrendingBeer, error) {
+	const recent = `
+	JOIN (
+		SELECT
+			beer_id,
+			COUNT(*) AS recent_reviews,
+			SUM(EXP(-LN(2) * EXTRACT(EPOCH FROM (?0::timestamp - created_at)) / ?1)) AS decayed
+		FROM reviews
+		WHERE status = ?2 AND deleted_at IS NULL AND created_at > ?3 AND created_at <= ?0
+		GROUP BY beer_id
+	) AS tr ON tr.beer_id = b.id`
+
+	const baseline = `
+	LEFT JOIN LATERAL (
+		SELECT COUNT(*) AS reviews
+		FROM reviews
+		WHERE beer_id = b.id AND status = ? AND deleted_at IS NULL AND created_at > ? AND created_at <= ?
+	) AS bl ON true`
+
+	start := now.Add(-window)
+	halfLife := (window / 2).Seconds()
+
+	var trending []dbTrendingBeer
+
+	query := s.selectBeers(&trending).
+		ColumnExpr("tr.recent_reviews").
+		ColumnExpr("(tr.decayed / (1 + bl.reviews::float / ?))::real AS trend_score", baselineWindows).
+		Join(recent, now, halfLife, beer.ReviewPublished, start).
+		Join(baseline, beer.ReviewPublished, start.Add(-baselineWindows*window), start).
+		OrderExpr("trend_score DESC").
+		OrderExpr("b.id ASC").
+		Limit(limit)
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, fmt.Errorf("querying trending beers [window=%s]: %w", window, err)
+	}
+
+	return toTrendingBeers(trending), nil
+}
+
+// RefreshRatings recomputes the Bayesian rating of every reviewed beer from
+// the beer stats and drops the ratings of the beers no longer reviewed.
+func (s Store) RefreshRatings(ctx context.Context, minVotes int, now time.Time) error {
+	const q = `
+	WITH prior AS (
+		SELECT COALESCE(AVG(score), 0) AS mean
+		FROM reviews
+		WHERE status = ?0 AND deleted_at IS NULL
+	)
+	INSERT INTO beer_ratings (beer_id, rating, computed_at)
+	SELECT bs.beer_id, (bs.review_count * bs.avg_score + ?1 * p.mean) / (bs.review_count + ?1), ?2
+	FROM beer_stats AS bs
+	CROSS JOIN prior AS p
+	WHERE bs.review_count > 0
+	ON CONFLICT (beer_id) DO UPDATE
+	SET rating = EXCLUDED.rating, computed_at = EXCLUDED.computed_at`
+
+	return s.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.ExecContext(ctx, q, beer.ReviewPublished, minVotes, now); err != nil {
+			return fmt.Errorf("computing beer ratings: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, "DELETE FROM beer_ratings WHERE computed_at < ?", now); err != nil {
+			return fmt.Errorf("deleting stale beer ratings: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// QueryTopBeers retrieves the beers that match the filter by their Bayesian
+// rating, the best first.
+func (s Store) QueryTopBeers(ctx context.Context, filter beer.QueryFilter, page int, size int) ([]beer.TopBeer, error) {
+	var top []dbTopBeer
+
+	query := s.selectBeers(&top).
+		ColumnExpr("br.rating").
+		Join("JOIN beer_ratings AS br ON br.beer_id = b.id").
+		OrderExpr("br.rating DESC").
+		OrderExpr("review_count DESC").
+		OrderExpr("b.id ASC").
+		Limit(size).
+		Offset(size * (page - 1))
+
+	applyFilter(query, filter)
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, fmt.Errorf("querying top beers: %w", err)
+	}
+
+	return toTopBeers(top), nil
+}
+
+// AddImage adds a new beer image to the database.
+func (s Store) AddImage(ctx context.Context, i beer.Image) error {
+	dbImage := toDBImage(i)
+
+	if _, err := s.db.NewInsert().Model(&dbImage).Exec(ctx); err != nil {
+		return fmt.Errorf("adding image: %w", err)
+	}
+
+	return nil
+}
+
+// QueryImages retrieves the images of a beer, the oldest first.
+func (s Store) QueryImages(ctx context.Context, beerID string) ([]beer.Image, error) {
+	var images []dbImage
+
+	query := s.db.NewSelect().
+		Model(&images).
+		Where("bi.beer_id = ?", beerID).
+		OrderExpr("bi.created_at ASC").
+		OrderExpr("bi.id ASC")
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, fmt.Errorf("querying images [beer_id=%s]: %w", beerID, err)
+	}
+
+	return toImages(images), nil
+}
+
+// SetBeerTags replaces the tags of a beer with the named ones. Tags that
+// don't exist yet are created as free-form tags.
+func (s Store) SetBeerTags(ctx context.Context, beerID string, names []string, now time.Time) error {
+	if _, err := s.db.NewDelete().TableExpr("beer_tags").Where("beer_id = ?", beerID).Exec(ctx); err != nil {
+		return fmt.Errorf("deleting beer tags [beer_id=%s]: %w", beerID, err)
+	}
+
+	if len(names) == 0 {
+		return nil
+	}
+
+	const addTags = `
+	INSERT INTO tags (id, name, curated, created_at)
+	SELECT gen_random_uuid(), name, FALSE, ?1
+	FROM unnest(?0::text[]) AS name
+	ON CONFLICT (name) DO NOTHING`
+
+	if _, err := s.db.ExecContext(ctx, addTags, pgdialect.Array(names), now); err != nil {
+		return fmt.Errorf("adding tags [beer_id=%s]: %w", beerID, err)
+	}
+
+	const linkTags = `
+	INSERT INTO beer_tags (beer_id, tag_id)
+	SELECT ?0, id FROM tags WHERE name IN (?1)`
+
+	if _, err := s.db.ExecContext(ctx, linkTags, beerID, bun.In(names)); err != nil {
+		return fmt.Errorf("linking tags [beer_id=%s]: %w", beerID, err)
+	}
+
+	return nil
+}
+
 // AddReview adds a new beer review to the database.
 func (s Store) AddReview(ctx context.Context, r beer.Review) error {
 	dbReview := toDBReview(r)
@@ -77,19 +607,486 @@ func (s Store) AddReview(ctx context.Context, r beer.Review) error {
 	return nil
 }
 
+// UpsertReview adds a review to the database, replacing the score and
+// comment of the review the user already wrote for the beer, if any. Its
+// return the stored review.
+func (s Store) UpsertReview(ctx context.Context, r beer.Review) (beer.Review, error) {
+	dbReview := toDBReview(r)
+
+	query := s.db.NewInsert().
+		Model(&dbReview).
+		On("CONFLICT (beer_id, user_id) WHERE deleted_at IS NULL DO UPDATE").
+		Set("score = EXCLUDED.score").
+		Set("comment = EXCLUDED.comment").
+		Set("status = CASE WHEN r.status = ? THEN r.status ELSE ? END", beer.ReviewPublished, beer.ReviewPending).
+		Returning("*")
+
+	if _, err := query.Exec(ctx); err != nil {
+		return beer.Review{}, fmt.Errorf("upserting review: %w", err)
+	}
+
+	return toReview(dbReview), nil
+}
+
+// UpdateReview replaces a review document in the database. The report count
+// is only changed through IncrementReportCount and ResetReportCount, and the
+// vote counts through UpdateReviewVotes.
+func (s Store) UpdateReview(ctx context.Context, r beer.Review) error {
+	dbReview := toDBReview(r)
+
+	query := s.db.NewUpdate().
+		Model(&dbReview).
+		ExcludeColumn("report_count", "helpful_count", "unhelpful_count", "helpfulness", "created_at").
+		WherePK()
+
+	if _, err := query.Exec(ctx); err != nil {
+		return fmt.Errorf("updating review [id=%s]: %w", r.ID, err)
+	}
+
+	return nil
+}
+
+// DeleteReview soft deletes a review, it's kept until purged.
+func (s Store) DeleteReview(ctx context.Context, reviewID string) error {
+	query := s.db.NewDelete().
+		Model((*dbReview)(nil)).
+		Where("id = ?", reviewID)
+
+	if _, err := query.Exec(ctx); err != nil {
+		return fmt.Errorf("deleting review [id=%s]: %w", reviewID, err)
+	}
+
+	return nil
+}
+
+// RestoreReview restores a deleted review. It returns sql.ErrNoRows when
+// there is no deleted review with the provided id.
+func (s Store) RestoreReview(ctx context.Context, reviewID string) error {
+	res, err := s.db.NewUpdate().
+		Model((*dbReview)(nil)).
+		Set("deleted_at = NULL").
+		Where("id = ?", reviewID).
+		WhereDeleted().
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("restoring review [id=%s]: %w", reviewID, err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("restoring review [id=%s]: %w", reviewID, err)
+	}
+	if n == 0 {
+		return fmt.Errorf("restoring review [id=%s]: %w", reviewID, sql.ErrNoRows)
+	}
+
+	return nil
+}
+
+// PurgeReviews hard deletes the reviews deleted before the time.
+func (s Store) PurgeReviews(ctx context.Context, before time.Time) error {
+	query := s.db.NewDelete().
+		Model((*dbReview)(nil)).
+		WhereDeleted().
+		Where("deleted_at < ?", before).
+		ForceDelete()
+
+	if _, err := query.Exec(ctx); err != nil {
+		return fmt.Errorf("purging reviews [before=%s]: %w", before, err)
+	}
+
+	return nil
+}
+
+// QueryReviewByID retrieves a review by its id.
+func (s Store) QueryReviewByID(ctx context.Context, reviewID string) (beer.Review, error) {
+	var r dbReview
+
+	query := s.selectReviews(&r).
+		Where("r.id = ?", reviewID)
+
+	if err := query.Scan(ctx); err != nil {
+		return beer.Review{}, fmt.Errorf("querying review by [id=%s]: %w", reviewID, err)
+	}
+
+	return toReview(r), nil
+}
+
+// UpdateBeerStats recalculates the review aggregates of a beer. The beer row
+// is locked first so concurrent reviews for the same beer are serialized and
+// every recalculation sees the reviews committed before it.
+func (s Store) UpdateBeerStats(ctx context.Context, beerID string) error {
+	lock := s.db.NewSelect().
+		Model((*dbBeer)(nil)).
+		Column("id").
+		Where("id = ?", beerID).
+		For("UPDATE")
+
+	if _, err := lock.Exec(ctx); err != nil {
+		return fmt.Errorf("locking beer [id=%s]: %w", beerID, err)
+	}
+
+	const q = `
+	INSERT INTO beer_stats (beer_id, review_count, avg_score, last_reviewed_at)
+	SELECT ?, COUNT(*), COALESCE(AVG(score), 0), MAX(created_at)
+	FROM reviews
+	WHERE beer_id = ? AND status = ? AND deleted_at IS NULL
+	ON CONFLICT (beer_id) DO UPDATE SET
+		review_count = EXCLUDED.review_count,
+		avg_score = EXCLUDED.avg_score,
+		last_reviewed_at = EXCLUDED.last_reviewed_at`
+
+	if _, err := s.db.ExecContext(ctx, q, beerID, beerID, beer.ReviewPublished); err != nil {
+		return fmt.Errorf("updating beer stats [id=%s]: %w", beerID, err)
+	}
+
+	return nil
+}
+
 // QueryBeerReviews retrieves a list of reviews for a beer.
-func (s Store) QueryBeerReviews(ctx context.Context, beerID string, page int, size int) ([]beer.Review, error) {
+func (s Store) QueryBeerReviews(ctx context.Context, beerID string, orderBy []order.By, page int, size int) ([]beer.Review, error) {
 	var reviews []dbReview
 
-	query := s.db.NewSelect().
-		Model(&reviews).
-		Where("beer_id =?", beerID).
+	query := s.selectReviews(&reviews).
+		Where("r.beer_id = ?", beerID).
+		Where("r.status = ?", beer.ReviewPublished).
 		Limit(size).
 		Offset(size * (page - 1))
 
+	if err := applyOrderBy(query, orderBy, reviewOrderByColumns, "r.id"); err != nil {
+		return nil, err
+	}
+
 	if err := query.Scan(ctx); err != nil {
 		return nil, fmt.Errorf("querying beer review [beer_id=%s]: %w", beerID, err)
 	}
 
 	return toReviews(reviews), nil
 }
+
+// QueryBeerReviewsByCursor retrieves a list of reviews for a beer, positioned
+// by the cursor.
+func (s Store) QueryBeerReviewsByCursor(ctx context.Context, beerID string, cur cursor.Cursor, limit int) ([]beer.Review, error) {
+	var reviews []dbReview
+
+	query := s.selectReviews(&reviews).
+		Where("r.beer_id = ?", beerID).
+		Where("r.status = ?", beer.ReviewPublished).
+		Limit(limit)
+
+	if err := applyCursor(query, cur, reviewOrderByColumns, "r.id"); err != nil {
+		return nil, err
+	}
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, fmt.Errorf("querying beer review [beer_id=%s]: %w", beerID, err)
+	}
+
+	if cur.Before {
+		reverse(reviews)
+	}
+
+	return toReviews(reviews), nil
+}
+
+// AddReport adds a report on a review to the database.
+func (s Store) AddReport(ctx context.Context, r beer.Report) error {
+	dbReport := toDBReport(r)
+
+	if _, err := s.db.NewInsert().Model(&dbReport).Exec(ctx); err != nil {
+		return fmt.Errorf("adding report: %w", err)
+	}
+
+	return nil
+}
+
+// IncrementReportCount adds one to the report count of a review. Its return
+// the new count. The review row stays locked until the transaction ends, so
+// concurrent reports of the same review are serialized.
+func (s Store) IncrementReportCount(ctx context.Context, reviewID string) (int, error) {
+	var count int
+
+	query := s.db.NewUpdate().
+		Model((*dbReview)(nil)).
+		Set("report_count = r.report_count + 1").
+		Where("id = ?", reviewID).
+		Returning("report_count")
+
+	if _, err := query.Exec(ctx, &count); err != nil {
+		return 0, fmt.Errorf("incrementing report count [id=%s]: %w", reviewID, err)
+	}
+
+	return count, nil
+}
+
+// ResetReportCount sets the report count of a review back to zero.
+func (s Store) ResetReportCount(ctx context.Context, reviewID string) error {
+	query := s.db.NewUpdate().
+		Model((*dbReview)(nil)).
+		Set("report_count = 0").
+		Where("id = ?", reviewID)
+
+	if _, err := query.Exec(ctx); err != nil {
+		return fmt.Errorf("resetting report count [id=%s]: %w", reviewID, err)
+	}
+
+	return nil
+}
+
+// ModerateReview records a moderator decision on a review, leaving the rest of
+// the review untouched. It returns sql.ErrNoRows when there is no review with
+// the provided id.
+func (s Store) ModerateReview(ctx context.Context, r beer.Review) error {
+	dbReview := toDBReview(r)
+
+	res, err := s.db.NewUpdate().
+		Model(&dbReview).
+		Column("status", "moderation_reason", "moderated_by", "moderated_at").
+		WherePK().
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("moderating review [id=%s]: %w", r.ID, err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("moderating review [id=%s]: %w", r.ID, err)
+	}
+	if n == 0 {
+		return fmt.Errorf("moderating review [id=%s]: %w", r.ID, sql.ErrNoRows)
+	}
+
+	return nil
+}
+
+// UpsertVote adds the vote of a user on a review to the database, replacing
+// the previous vote of the user, if any.
+func (s Store) UpsertVote(ctx context.Context, v beer.Vote) error {
+	dbVote := toDBVote(v)
+
+	query := s.db.NewInsert().
+		Model(&dbVote).
+		On("CONFLICT (review_id, user_id) DO UPDATE").
+		Set("helpful = EXCLUDED.helpful").
+		Set("created_at = EXCLUDED.created_at")
+
+	if _, err := query.Exec(ctx); err != nil {
+		return fmt.Errorf("upserting vote: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateReviewVotes recalculates the vote counts and the helpfulness score of
+// a review. The review row is locked first so concurrent votes on the same
+// review are serialized and every recalculation sees the votes committed
+// before it.
+func (s Store) UpdateReviewVotes(ctx context.Context, reviewID string) error {
+	lock := s.db.NewSelect().
+		Model((*dbReview)(nil)).
+		Column("id").
+		Where("id = ?", reviewID).
+		For("UPDATE")
+
+	if _, err := lock.Exec(ctx); err != nil {
+		return fmt.Errorf("locking review [id=%s]: %w", reviewID, err)
+	}
+
+	const q = `
+	UPDATE reviews SET
+		helpful_count = v.helpful,
+		unhelpful_count = v.unhelpful,
+		helpfulness = wilson_lower_bound(v.helpful, v.unhelpful)
+	FROM (
+		SELECT
+			COUNT(*) FILTER (WHERE helpful)::int AS helpful,
+			COUNT(*) FILTER (WHERE NOT helpful)::int AS unhelpful
+		FROM review_votes
+		WHERE review_id = ?
+	) v
+	WHERE id = ?`
+
+	if _, err := s.db.ExecContext(ctx, q, reviewID, reviewID); err != nil {
+		return fmt.Errorf("updating review votes [id=%s]: %w", reviewID, err)
+	}
+
+	return nil
+}
+
+// QueryModerationQueue retrieves the reviews waiting for a moderator, the
+// most reported first.
+func (s Store) QueryModerationQueue(ctx context.Context, page int, size int) ([]beer.Review, error) {
+	var reviews []dbReview
+
+	query := s.selectReviews(&reviews).
+		Where("r.status IN (?)", bun.In([]string{beer.ReviewPending, beer.ReviewHidden})).
+		OrderExpr("r.report_count DESC, r.created_at ASC, r.id ASC").
+		Limit(size).
+		Offset(size * (page - 1))
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, fmt.Errorf("querying moderation queue: %w", err)
+	}
+
+	return toReviews(reviews), nil
+}
+
+// SaveScoresheet adds the scoresheet of a review to the database, replacing
+// the previous one, if any.
+func (s Store) SaveScoresheet(ctx context.Context, reviewID string, sheet beer.Scoresheet) error {
+	dbScoresheet := toDBScoresheet(reviewID, sheet)
+
+	query := s.db.NewInsert().
+		Model(&dbScoresheet).
+		On("CONFLICT (review_id) DO UPDATE").
+		Set("aroma = EXCLUDED.aroma").
+		Set("appearance = EXCLUDED.appearance").
+		Set("flavor = EXCLUDED.flavor").
+		Set("mouthfeel = EXCLUDED.mouthfeel").
+		Set("overall = EXCLUDED.overall")
+
+	if _, err := query.Exec(ctx); err != nil {
+		return fmt.Errorf("saving scoresheet [review_id=%s]: %w", reviewID, err)
+	}
+
+	return nil
+}
+
+// DeleteScoresheet removes the scoresheet of a review from the database.
+func (s Store) DeleteScoresheet(ctx context.Context, reviewID string) error {
+	query := s.db.NewDelete().
+		Model((*dbScoresheet)(nil)).
+		Where("review_id = ?", reviewID)
+
+	if _, err := query.Exec(ctx); err != nil {
+		return fmt.Errorf("deleting scoresheet [review_id=%s]: %w", reviewID, err)
+	}
+
+	return nil
+}
+
+// QueryScoresheetStats retrieves the per section averages of the scoresheets
+// of the published reviews of a beer.
+func (s Store) QueryScoresheetStats(ctx context.Context, beerID string) (beer.ScoresheetStats, error) {
+	var stats dbScoresheetStats
+
+	query := s.db.NewSelect().
+		Model((*dbScoresheet)(nil)).
+		ColumnExpr("COUNT(*) AS count").
+		ColumnExpr("COALESCE(AVG(rs.aroma), 0)::real AS aroma").
+		ColumnExpr("COALESCE(AVG(rs.appearance), 0)::real AS appearance").
+		ColumnExpr("COALESCE(AVG(rs.flavor), 0)::real AS flavor").
+		ColumnExpr("COALESCE(AVG(rs.mouthfeel), 0)::real AS mouthfeel").
+		ColumnExpr("COALESCE(AVG(rs.overall), 0)::real AS overall").
+		Join("JOIN reviews AS r ON r.id = rs.review_id").
+		Where("r.beer_id = ?", beerID).
+		Where("r.status = ?", beer.ReviewPublished).
+		Where("r.deleted_at IS NULL")
+
+	if err := query.Scan(ctx, &stats); err != nil {
+		return beer.ScoresheetStats{}, fmt.Errorf("querying scoresheet stats [beer_id=%s]: %w", beerID, err)
+	}
+
+	return toScoresheetStats(stats), nil
+}
+
+// selectReviews builds the base query used to read reviews along with their
+// scoresheet, if any. The reviews of deleted beers are left out along with
+// the deleted reviews.
+func (s Store) selectReviews(model any) *bun.SelectQuery {
+	return s.db.NewSelect().
+		Model(model).
+		ColumnExpr("?TableColumns").
+		ColumnExpr("rs.aroma, rs.appearance, rs.flavor, rs.mouthfeel, rs.overall").
+		Join("LEFT JOIN review_scoresheets AS rs ON rs.review_id = r.id").
+		Where("EXISTS (SELECT 1 FROM beers AS rb WHERE rb.id = r.beer_id AND rb.deleted_at IS NULL)")
+}
+
+// selectBeers builds the base query used to read beers along with their
+// review aggregates.
+func (s Store) selectBeers(model any) *bun.SelectQuery {
+	return s.db.NewSelect().
+		Model(model).
+		ColumnExpr("?TableColumns").
+		ColumnExpr("COALESCE(bs.avg_score, 0) AS score").
+		ColumnExpr("COALESCE(bs.review_count, 0) AS review_count").
+		ColumnExpr("bs.last_reviewed_at").
+		ColumnExpr("COALESCE(bs.checkin_count, 0) AS checkin_count").
+		ColumnExpr("ARRAY(SELECT bi.url FROM beer_images AS bi WHERE bi.beer_id = b.id ORDER BY bi.created_at, bi.id) AS image_urls").
+		ColumnExpr("ARRAY(SELECT t.name FROM beer_tags AS bt JOIN tags AS t ON t.id = bt.tag_id WHERE bt.beer_id = b.id ORDER BY t.name) AS tags").
+		Join("LEFT JOIN beer_stats AS bs ON bs.beer_id = b.id")
+}
+
+// applyFilter adds a where clause to the query for every field set in the
+// filter.
+func applyFilter(query *bun.SelectQuery, filter beer.QueryFilter) {
+	if filter.Style != nil {
+		query.Where("LOWER(b.style) = LOWER(?)", *filter.Style)
+	}
+	if filter.Brewery != nil {
+		query.Where("LOWER(b.brewery) = LOWER(?)", *filter.Brewery)
+	}
+	if filter.BreweryID != nil {
+		query.Where("b.brewery_id = ?", *filter.BreweryID)
+	}
+	if filter.Name != nil {
+		query.Where("b.name ILIKE ?", "%"+escapeLike(*filter.Name)+"%")
+	}
+	if filter.MinABV != nil {
+		query.Where("b.abv >= ?", *filter.MinABV)
+	}
+	if filter.MaxABV != nil {
+		query.Where("b.abv <= ?", *filter.MaxABV)
+	}
+	if filter.CreatedAfter != nil {
+		query.Where("b.created_at >= ?", *filter.CreatedAfter)
+	}
+	if filter.CreatedBefore != nil {
+		query.Where("b.created_at <= ?", *filter.CreatedBefore)
+	}
+	if filter.MinScore != nil {
+		query.Where("COALESCE(bs.avg_score, 0) >= ?", *filter.MinScore)
+	}
+	if filter.IncludeDeleted {
+		query.WhereAllWithDeleted()
+	}
+	if len(filter.Tags) > 0 {
+		const matching = "FROM beer_tags AS bt JOIN tags AS t ON t.id = bt.tag_id WHERE bt.beer_id = b.id AND t.name IN (?)"
+		switch filter.TagMatch {
+		case beer.TagMatchAll:
+			query.Where("(SELECT COUNT(*) "+matching+") = ?", bun.In(filter.Tags), len(filter.Tags))
+		default:
+			query.Where("EXISTS (SELECT 1 "+matching+")", bun.In(filter.Tags))
+		}
+	}
+}
+
+// escapeLike escapes the LIKE wildcard characters so user input is matched
+// literally.
+func escapeLike(v string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(v)
+}
+
+// reverse reverses the order of the elements in place.
+func reverse[T any](list []T) {
+	for i, j := 0, len(list)-1; i < j; i, j = i+1, j-1 {
+		list[i], list[j] = list[j], list[i]
+	}
+}
+
+// toTSQuery converts free text into a tsquery expression matching every word
+// as a prefix, for example "hazy ip" becomes "hazy:* & ip:*". Any character
+// that isn't a letter or a digit is treated as a word separator so user input
+// can't inject tsquery operators.
+func toTSQuery(text string) string {
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	terms := make([]string, len(words))
+	for i, word := range words {
+		terms[i] = strings.ToLower(word) + ":*"
+	}
+
+	return strings.Join(terms, " & ")
+}
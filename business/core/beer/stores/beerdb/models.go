@@ -1,6 +1,7 @@
 package beerdb
 
 import (
+	"database/sql"
 	"time"
 
 	"github.com/phbpx/gobeers/business/core/beer"
@@ -11,24 +12,145 @@ import (
 type dbBeer struct {
 	bun.BaseModel `bun:"table:beers,alias:b"`
 
-	ID        string    `bun:"id,pk"`
-	Name      string    `bun:"name"`
-	Brewery   string    `bun:"brewery"`
-	Style     string    `bun:"style"`
-	ABV       float32   `bun:"abv"`
-	ShortDesc string    `bun:"short_desc"`
-	CreatedAt time.Time `bun:"created_at"`
+	ID             string     `bun:"id,pk"`
+	Name           string     `bun:"name"`
+	BreweryID      string     `bun:"brewery_id"`
+	Brewery        string     `bun:"brewery"`
+	Style          string     `bun:"style"`
+	ABV            float32    `bun:"abv"`
+	ShortDesc      string     `bun:"short_desc"`
+	NormalizedName string     `bun:"normalized_name"`
+	Forced         bool       `bun:"forced"`
+	CreatedAt      time.Time  `bun:"created_at"`
+	DeletedAt      *time.Time `bun:"deleted_at,soft_delete"`
+
+	Score          float32    `bun:"score,scanonly"`
+	ReviewCount    int        `bun:"review_count,scanonly"`
+	LastReviewedAt *time.Time `bun:"last_reviewed_at,scanonly"`
+	CheckinCount   int        `bun:"checkin_count,scanonly"`
+	ImageURLs      []string   `bun:"image_urls,array,scanonly"`
+	Tags           []string   `bun:"tags,array,scanonly"`
+}
+
+// dbImage defines the properties of a beer image.
+type dbImage struct {
+	bun.BaseModel `bun:"table:beer_images,alias:bi"`
+
+	ID          string    `bun:"id,pk"`
+	BeerID      string    `bun:"beer_id"`
+	Key         string    `bun:"key"`
+	URL         string    `bun:"url"`
+	ContentType string    `bun:"content_type"`
+	Size        int       `bun:"size"`
+	CreatedAt   time.Time `bun:"created_at"`
+}
+
+// dbSearchResult represents a beer matched by a full text search.
+type dbSearchResult struct {
+	dbBeer `bun:",extend"`
+
+	Rank    float32 `bun:"rank,scanonly"`
+	Snippet string  `bun:"snippet,scanonly"`
+}
+
+// dbSimilarBeer represents a beer ranked by its similarity to another beer.
+type dbSimilarBeer struct {
+	dbBeer `bun:",extend"`
+
+	Similarity float32 `bun:"similarity,scanonly"`
+}
+
+// dbTrendingBeer represents a beer ranked by its recent review velocity.
+type dbTrendingBeer struct {
+	dbBeer `bun:",extend"`
+
+	TrendScore    float32 `bun:"trend_score,scanonly"`
+	RecentReviews int     `bun:"recent_reviews,scanonly"`
+}
+
+// dbTopBeer represents a beer ranked by its Bayesian rating.
+type dbTopBeer struct {
+	dbBeer `bun:",extend"`
+
+	Rating float32 `bun:"rating,scanonly"`
+}
+
+// dbSuggestion represents a beer name or brewery matching a typeahead prefix.
+type dbSuggestion struct {
+	Text   string         `bun:"text"`
+	Kind   string         `bun:"kind"`
+	BeerID sql.NullString `bun:"beer_id"`
+	Score  float32        `bun:"score"`
 }
 
 // dbReview defines the properties of a review.
 type dbReview struct {
 	bun.BaseModel `bun:"table:reviews,alias:r"`
 
+	ID               string     `bun:"id,pk"`
+	BeerID           string     `bun:"beer_id"`
+	UserID           string     `bun:"user_id"`
+	Score            float32    `bun:"score"`
+	Comment          string     `bun:"comment"`
+	Status           string     `bun:"status"`
+	ReportCount      int        `bun:"report_count"`
+	HelpfulCount     int        `bun:"helpful_count"`
+	UnhelpfulCount   int        `bun:"unhelpful_count"`
+	Helpfulness      float32    `bun:"helpfulness"`
+	ModerationReason string     `bun:"moderation_reason,nullzero"`
+	ModeratedBy      string     `bun:"moderated_by,nullzero"`
+	ModeratedAt      *time.Time `bun:"moderated_at"`
+	CreatedAt        time.Time  `bun:"created_at"`
+	DeletedAt        *time.Time `bun:"deleted_at,soft_delete"`
+
+	Aroma      *float32 `bun:"aroma,scanonly"`
+	Appearance *float32 `bun:"appearance,scanonly"`
+	Flavor     *float32 `bun:"flavor,scanonly"`
+	Mouthfeel  *float32 `bun:"mouthfeel,scanonly"`
+	Overall    *float32 `bun:"overall,scanonly"`
+}
+
+// dbScoresheet defines the structured tasting evaluation of a review.
+type dbScoresheet struct {
+	bun.BaseModel `bun:"table:review_scoresheets,alias:rs"`
+
+	ReviewID   string  `bun:"review_id,pk"`
+	Aroma      float32 `bun:"aroma"`
+	Appearance float32 `bun:"appearance"`
+	Flavor     float32 `bun:"flavor"`
+	Mouthfeel  float32 `bun:"mouthfeel"`
+	Overall    float32 `bun:"overall"`
+}
+
+// dbScoresheetStats holds the per section averages of the scoresheets of a
+// beer.
+type dbScoresheetStats struct {
+	Count      int     `bun:"count"`
+	Aroma      float32 `bun:"aroma"`
+	Appearance float32 `bun:"appearance"`
+	Flavor     float32 `bun:"flavor"`
+	Mouthfeel  float32 `bun:"mouthfeel"`
+	Overall    float32 `bun:"overall"`
+}
+
+// dbVote defines the helpfulness vote of a user on a review.
+type dbVote struct {
+	bun.BaseModel `bun:"table:review_votes,alias:rv"`
+
+	ReviewID  string    `bun:"review_id,pk"`
+	UserID    string    `bun:"user_id,pk"`
+	Helpful   bool      `bun:"helpful"`
+	CreatedAt time.Time `bun:"created_at"`
+}
+
+// dbReport defines the properties of a user report on a review.
+type dbReport struct {
+	bun.BaseModel `bun:"table:review_reports,alias:rr"`
+
 	ID        string    `bun:"id,pk"`
-	BeerID    string    `bun:"beer_id"`
+	ReviewID  string    `bun:"review_id"`
 	UserID    string    `bun:"user_id"`
-	Score     float32   `bun:"score"`
-	Comment   string    `bun:"comment"`
+	Reason    string    `bun:"reason"`
 	CreatedAt time.Time `bun:"created_at"`
 }
 
@@ -36,25 +158,49 @@ type dbReview struct {
 
 func toDBBeer(b beer.Beer) dbBeer {
 	return dbBeer{
-		ID:        b.ID,
-		Name:      b.Name,
-		Brewery:   b.Brewery,
-		Style:     b.Style,
-		ABV:       b.ABV,
-		ShortDesc: b.ShortDesc,
-		CreatedAt: b.CreatedAt,
+		ID:             b.ID,
+		Name:           b.Name,
+		BreweryID:      b.BreweryID,
+		Brewery:        b.Brewery,
+		Style:          b.Style,
+		ABV:            b.ABV,
+		ShortDesc:      b.ShortDesc,
+		NormalizedName: b.NormalizedName,
+		Forced:         b.Forced,
+		CreatedAt:      b.CreatedAt,
+		DeletedAt:      b.DeletedAt,
 	}
 }
 
 func toBeer(b dbBeer) beer.Beer {
+	imageURLs := b.ImageURLs
+	if imageURLs == nil {
+		imageURLs = []string{}
+	}
+
+	tags := b.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
 	return beer.Beer{
-		ID:        b.ID,
-		Name:      b.Name,
-		Brewery:   b.Brewery,
-		Style:     b.Style,
-		ABV:       b.ABV,
-		ShortDesc: b.ShortDesc,
-		CreatedAt: b.CreatedAt,
+		ID:             b.ID,
+		Name:           b.Name,
+		BreweryID:      b.BreweryID,
+		Brewery:        b.Brewery,
+		Style:          b.Style,
+		ABV:            b.ABV,
+		ShortDesc:      b.ShortDesc,
+		Score:          b.Score,
+		ReviewCount:    b.ReviewCount,
+		LastReviewedAt: b.LastReviewedAt,
+		CheckinCount:   b.CheckinCount,
+		ImageURLs:      imageURLs,
+		Tags:           tags,
+		NormalizedName: b.NormalizedName,
+		Forced:         b.Forced,
+		CreatedAt:      b.CreatedAt,
+		DeletedAt:      b.DeletedAt,
 	}
 }
 
@@ -66,25 +212,142 @@ func toBeers(list []dbBeer) []beer.Beer {
 	return beers
 }
 
+func toDBImage(i beer.Image) dbImage {
+	return dbImage{
+		ID:          i.ID,
+		BeerID:      i.BeerID,
+		Key:         i.Key,
+		URL:         i.URL,
+		ContentType: i.ContentType,
+		Size:        i.Size,
+		CreatedAt:   i.CreatedAt,
+	}
+}
+
+func toImages(list []dbImage) []beer.Image {
+	images := make([]beer.Image, len(list))
+	for i, img := range list {
+		images[i] = beer.Image{
+			ID:          img.ID,
+			BeerID:      img.BeerID,
+			Key:         img.Key,
+			URL:         img.URL,
+			ContentType: img.ContentType,
+			Size:        img.Size,
+			CreatedAt:   img.CreatedAt,
+		}
+	}
+	return images
+}
+
+func toSearchResults(list []dbSearchResult) []beer.SearchResult {
+	results := make([]beer.SearchResult, len(list))
+	for i, r := range list {
+		results[i] = beer.SearchResult{
+			Beer:    toBeer(r.dbBeer),
+			Rank:    r.Rank,
+			Snippet: r.Snippet,
+		}
+	}
+	return results
+}
+
+func toSimilarBeers(list []dbSimilarBeer) []beer.SimilarBeer {
+	similar := make([]beer.SimilarBeer, len(list))
+	for i, b := range list {
+		similar[i] = beer.SimilarBeer{
+			Beer:       toBeer(b.dbBeer),
+			Similarity: b.Similarity,
+		}
+	}
+	return similar
+}
+
+func toTrendingBeers(list []dbTrendingBeer) []beer.TrendingBeer {
+	trending := make([]beer.TrendingBeer, len(list))
+	for i, b := range list {
+		trending[i] = beer.TrendingBeer{
+			Beer:          toBeer(b.dbBeer),
+			TrendScore:    b.TrendScore,
+			RecentReviews: b.RecentReviews,
+		}
+	}
+	return trending
+}
+
+func toTopBeers(list []dbTopBeer) []beer.TopBeer {
+	top := make([]beer.TopBeer, len(list))
+	for i, b := range list {
+		top[i] = beer.TopBeer{
+			Beer:   toBeer(b.dbBeer),
+			Rating: b.Rating,
+		}
+	}
+	return top
+}
+
+func toSuggestions(list []dbSuggestion) []beer.Suggestion {
+	suggestions := make([]beer.Suggestion, len(list))
+	for i, s := range list {
+		suggestions[i] = beer.Suggestion{
+			Text:   s.Text,
+			Kind:   s.Kind,
+			BeerID: s.BeerID.String,
+			Score:  s.Score,
+		}
+	}
+	return suggestions
+}
+
 func toDBReview(r beer.Review) dbReview {
 	return dbReview{
-		ID:        r.ID,
-		BeerID:    r.BeerID,
-		UserID:    r.UserID,
-		Score:     r.Score,
-		Comment:   r.Comment,
-		CreatedAt: r.CreatedAt,
+		ID:               r.ID,
+		BeerID:           r.BeerID,
+		UserID:           r.UserID,
+		Score:            r.Score,
+		Comment:          r.Comment,
+		Status:           r.Status,
+		ReportCount:      r.ReportCount,
+		HelpfulCount:     r.HelpfulCount,
+		UnhelpfulCount:   r.UnhelpfulCount,
+		Helpfulness:      r.Helpfulness,
+		ModerationReason: r.ModerationReason,
+		ModeratedBy:      r.ModeratedBy,
+		ModeratedAt:      r.ModeratedAt,
+		CreatedAt:        r.CreatedAt,
+		DeletedAt:        r.DeletedAt,
 	}
 }
 
 func toReview(r dbReview) beer.Review {
+	var sheet *beer.Scoresheet
+	if r.Aroma != nil {
+		sheet = &beer.Scoresheet{
+			Aroma:      *r.Aroma,
+			Appearance: *r.Appearance,
+			Flavor:     *r.Flavor,
+			Mouthfeel:  *r.Mouthfeel,
+			Overall:    *r.Overall,
+		}
+	}
+
 	return beer.Review{
-		ID:        r.ID,
-		BeerID:    r.BeerID,
-		UserID:    r.UserID,
-		Score:     r.Score,
-		Comment:   r.Comment,
-		CreatedAt: r.CreatedAt,
+		ID:               r.ID,
+		BeerID:           r.BeerID,
+		UserID:           r.UserID,
+		Score:            r.Score,
+		Comment:          r.Comment,
+		Status:           r.Status,
+		ReportCount:      r.ReportCount,
+		HelpfulCount:     r.HelpfulCount,
+		UnhelpfulCount:   r.UnhelpfulCount,
+		Helpfulness:      r.Helpfulness,
+		Scoresheet:       sheet,
+		ModerationReason: r.ModerationReason,
+		ModeratedBy:      r.ModeratedBy,
+		ModeratedAt:      r.ModeratedAt,
+		CreatedAt:        r.CreatedAt,
+		DeletedAt:        r.DeletedAt,
 	}
 }
 
@@ -95,3 +358,45 @@ func toReviews(list []dbReview) []beer.Review {
 	}
 	return reviews
 }
+
+func toDBReport(r beer.Report) dbReport {
+	return dbReport{
+		ID:        r.ID,
+		ReviewID:  r.ReviewID,
+		UserID:    r.UserID,
+		Reason:    r.Reason,
+		CreatedAt: r.CreatedAt,
+	}
+}
+
+func toDBVote(v beer.Vote) dbVote {
+	return dbVote{
+		ReviewID:  v.ReviewID,
+		UserID:    v.UserID,
+		Helpful:   v.Helpful,
+		CreatedAt: v.CreatedAt,
+	}
+}
+
+func toDBScoresheet(reviewID string, s beer.Scoresheet) dbScoresheet {
+	return dbScoresheet{
+		ReviewID:   reviewID,
+		Aroma:      s.Aroma,
+		Appearance: s.Appearance,
+		Flavor:     s.Flavor,
+		Mouthfeel:  s.Mouthfeel,
+		Overall:    s.Overall,
+	}
+}
+
+func toScoresheetStats(s dbScoresheetStats) beer.ScoresheetStats {
+	return beer.ScoresheetStats{
+		Count:      s.Count,
+		Aroma:      s.Aroma,
+		Appearance: s.Appearance,
+		Flavor:     s.Flavor,
+		Mouthfeel:  s.Mouthfeel,
+		Overall:    s.Overall,
+		Total:      s.Aroma + s.Appearance + s.Flavor + s.Mouthfeel + s.Overall,
+	}
+}
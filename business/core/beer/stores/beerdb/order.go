@@ -0,0 +1,117 @@
+package beerdb
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/phbpx/gobeers/business/core/beer"
+	"github.com/phbpx/gobeers/business/sys/cursor"
+	"github.com/phbpx/gobeers/business/sys/order"
+	"github.com/uptrace/bun"
+)
+
+// The float columns are compared as real values, the precision the core
+// layer works with, so the sort keys stored in a cursor match exactly.
+
+// beerOrderByColumns maps the beer order by fields to the SQL expression
+// used to sort them.
+var beerOrderByColumns = map[string]string{
+	beer.OrderByID:        "b.id",
+	beer.OrderByName:      "b.name",
+	beer.OrderByBrewery:   "b.brewery",
+	beer.OrderByStyle:     "b.style",
+	beer.OrderByABV:       "b.abv::real",
+	beer.OrderByScore:     "COALESCE(bs.avg_score, 0)::real",
+	beer.OrderByCreatedAt: "b.created_at",
+}
+
+// reviewOrderByColumns maps the review order by fields to the SQL expression
+// used to sort them.
+var reviewOrderByColumns = map[string]string{
+	beer.OrderByID:          "r.id",
+	beer.OrderByScore:       "r.score::real",
+	beer.OrderByCreatedAt:   "r.created_at",
+	beer.OrderByHelpfulness: "r.helpfulness",
+}
+
+// applyOrderBy adds the ORDER BY clause to the query. The tiebreaker column
+// is always appended so rows with equal sort keys keep a stable order
+// across pages.
+func applyOrderBy(query *bun.SelectQuery, orderBy []order.By, columns map[string]string, tiebreaker string) error {
+	for _, by := range orderBy {
+		column, exists := columns[by.Field]
+		if !exists {
+			return fmt.Errorf("%w: %s", order.ErrInvalidField, by.Field)
+		}
+
+		direction := order.ASC
+		if by.Direction == order.DESC {
+			direction = order.DESC
+		}
+
+		query.OrderExpr(column + " " + direction)
+	}
+
+	query.OrderExpr(tiebreaker + " " + order.ASC)
+
+	return nil
+}
+
+// applyCursor positions the query right after the row the cursor points to
+// and orders it by the cursor ordering plus the tiebreaker column. When the
+// cursor points backwards the ordering is reversed, so callers must reverse
+// the rows they get back.
+func applyCursor(query *bun.SelectQuery, cur cursor.Cursor, columns map[string]string, tiebreaker string) error {
+	cols := make([]string, 0, len(cur.OrderBy)+1)
+	asc := make([]bool, 0, len(cur.OrderBy)+1)
+
+	for _, by := range cur.OrderBy {
+		column, exists := columns[by.Field]
+		if !exists {
+			return fmt.Errorf("%w: %s", order.ErrInvalidField, by.Field)
+		}
+
+		cols = append(cols, column)
+		asc = append(asc, (by.Direction != order.DESC) != cur.Before)
+	}
+
+	cols = append(cols, tiebreaker)
+	asc = append(asc, !cur.Before)
+
+	// The keyset predicate for columns (a, b, id) reads:
+	// (a > ?) OR (a = ? AND b > ?) OR (a = ? AND b = ? AND id > ?)
+	if !cur.IsStart() {
+		values := append(append([]string{}, cur.Values...), cur.ID)
+
+		var ors []string
+		var args []any
+		for i := range cols {
+			var ands []string
+			for j := 0; j < i; j++ {
+				ands = append(ands, cols[j]+" = ?")
+				args = append(args, values[j])
+			}
+
+			op := " > ?"
+			if !asc[i] {
+				op = " < ?"
+			}
+			ands = append(ands, cols[i]+op)
+			args = append(args, values[i])
+
+			ors = append(ors, "("+strings.Join(ands, " AND ")+")")
+		}
+
+		query.Where(strings.Join(ors, " OR "), args...)
+	}
+
+	for i, column := range cols {
+		direction := order.ASC
+		if !asc[i] {
+			direction = order.DESC
+		}
+		query.OrderExpr(column + " " + direction)
+	}
+
+	return nil
+}
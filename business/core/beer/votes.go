@@ -0,0 +1,69 @@
+package beer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/phbpx/gobeers/business/sys/validate"
+)
+
+// VoteReview records whether the user found a published review helpful. A
+// user has a single vote per review, voting again replaces the previous vote.
+// Its return the review with the updated vote counts.
+func (c Core) VoteReview(ctx context.Context, userID string, reviewID string, nv NewVote, now time.Time) (Review, error) {
+	if err := validate.CheckID(reviewID); err != nil {
+		return Review{}, ErrInvalidID
+	}
+
+	if err := validate.CheckID(userID); err != nil {
+		return Review{}, ErrForbidden
+	}
+
+	if err := validate.Check(nv); err != nil {
+		return Review{}, fmt.Errorf("validating data: %w", err)
+	}
+
+	review, err := c.queryReview(ctx, reviewID)
+	if err != nil {
+		return Review{}, err
+	}
+
+	if review.Status != ReviewPublished {
+		return Review{}, ErrReviewNotFound
+	}
+
+	if review.UserID == userID {
+		return Review{}, ErrForbidden
+	}
+
+	vote := Vote{
+		ReviewID:  review.ID,
+		UserID:    userID,
+		Helpful:   *nv.Helpful,
+		CreatedAt: now,
+	}
+
+	tran := func(s Storer) error {
+		if err := s.UpsertVote(ctx, vote); err != nil {
+			return fmt.Errorf("upsertVote: %w", err)
+		}
+		if err := s.UpdateReviewVotes(ctx, review.ID); err != nil {
+			return fmt.Errorf("updateReviewVotes: %w", err)
+		}
+
+		voted, err := s.QueryReviewByID(ctx, review.ID)
+		if err != nil {
+			return fmt.Errorf("queryReviewByID: %w", err)
+		}
+		review = voted
+
+		return nil
+	}
+
+	if err := c.store.WithinTran(ctx, tran); err != nil {
+		return Review{}, fmt.Errorf("tran: %w", err)
+	}
+
+	return review, nil
+}
@@ -0,0 +1,159 @@
+// Package brewery provides the core business API for breweries.
+package brewery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phbpx/gobeers/business/sys/database"
+	"github.com/phbpx/gobeers/business/sys/validate"
+)
+
+// Set of error variables for CRUD operations.
+var (
+	ErrNotFound   = errors.New("brewery not found")
+	ErrInvalidID  = errors.New("ID is not in its proper form")
+	ErrUniqueName = errors.New("brewery name already exists")
+	ErrHasBeers   = errors.New("brewery still has beers")
+)
+
+// Storer interface declares the behavior this package needs to persist and
+// retrieve data.
+type Storer interface {
+	AddBrewery(ctx context.Context, brewery Brewery) error
+	UpdateBrewery(ctx context.Context, brewery Brewery) error
+	DeleteBrewery(ctx context.Context, breweryID string) error
+	QueryBreweries(ctx context.Context, page int, size int) ([]Brewery, error)
+	QueryBreweryByID(ctx context.Context, breweryID string) (Brewery, error)
+}
+
+// Core manages the set of APIs for brewery access.
+type Core struct {
+	store Storer
+}
+
+// NewCore constructs a core for brewery api access.
+func NewCore(store Storer) Core {
+	return Core{
+		store: store,
+	}
+}
+
+// Create adds a brewery to the database. Its return the created Brewery
+// with fields populated.
+func (c Core) Create(ctx context.Context, nb NewBrewery, now time.Time) (Brewery, error) {
+	if err := validate.Check(nb); err != nil {
+		return Brewery{}, fmt.Errorf("validating data: %w", err)
+	}
+
+	brewery := Brewery{
+		ID:          uuid.New().String(),
+		Name:        nb.Name,
+		Country:     nb.Country,
+		City:        nb.City,
+		Website:     nb.Website,
+		FoundedYear: nb.FoundedYear,
+		CreatedAt:   now,
+	}
+
+	if err := c.store.AddBrewery(ctx, brewery); err != nil {
+		if database.IsIntegrityViolation(err) {
+			return Brewery{}, ErrUniqueName
+		}
+		return Brewery{}, fmt.Errorf("addBrewery: %w", err)
+	}
+
+	return brewery, nil
+}
+
+// Update replaces the fields of a brewery that are set in the UpdateBrewery
+// value. Its return the updated Brewery.
+func (c Core) Update(ctx context.Context, breweryID string, ub UpdateBrewery) (Brewery, error) {
+	if err := validate.CheckID(breweryID); err != nil {
+		return Brewery{}, ErrInvalidID
+	}
+
+	if err := validate.Check(ub); err != nil {
+		return Brewery{}, fmt.Errorf("validating data: %w", err)
+	}
+
+	brewery, err := c.QueryByID(ctx, breweryID)
+	if err != nil {
+		return Brewery{}, err
+	}
+
+	if ub.Name != nil {
+		brewery.Name = *ub.Name
+	}
+	if ub.Country != nil {
+		brewery.Country = *ub.Country
+	}
+	if ub.City != nil {
+		brewery.City = *ub.City
+	}
+	if ub.Website != nil {
+		brewery.Website = *ub.Website
+	}
+	if ub.FoundedYear != nil {
+		brewery.FoundedYear = *ub.FoundedYear
+	}
+
+	if err := c.store.UpdateBrewery(ctx, brewery); err != nil {
+		if database.IsIntegrityViolation(err) {
+			return Brewery{}, ErrUniqueName
+		}
+		return Brewery{}, fmt.Errorf("updateBrewery: %w", err)
+	}
+
+	return brewery, nil
+}
+
+// Delete removes the specified brewery from the database. A brewery that
+// still has beers can't be removed.
+func (c Core) Delete(ctx context.Context, breweryID string) error {
+	if err := validate.CheckID(breweryID); err != nil {
+		return ErrInvalidID
+	}
+
+	if err := c.store.DeleteBrewery(ctx, breweryID); err != nil {
+		switch {
+		case database.IsNoRowError(err):
+			return ErrNotFound
+		case database.IsIntegrityViolation(err):
+			return ErrHasBeers
+		}
+		return fmt.Errorf("deleteBrewery: %w", err)
+	}
+
+	return nil
+}
+
+// QueryByID gets the specified brewery from the database.
+func (c Core) QueryByID(ctx context.Context, breweryID string) (Brewery, error) {
+	if err := validate.CheckID(breweryID); err != nil {
+		return Brewery{}, ErrInvalidID
+	}
+
+	brewery, err := c.store.QueryBreweryByID(ctx, breweryID)
+	if err != nil {
+		if database.IsNoRowError(err) {
+			return Brewery{}, ErrNotFound
+		}
+		return Brewery{}, fmt.Errorf("queryBreweryByID: %w", err)
+	}
+
+	return brewery, nil
+}
+
+// Query gets all breweries from the database.
+func (c Core) Query(ctx context.Context, page int, size int) ([]Brewery, error) {
+	breweries, err := c.store.QueryBreweries(ctx, page, size)
+	if err != nil {
+		return nil, fmt.Errorf("queryBreweries: %w", err)
+	}
+
+	return breweries, nil
+}
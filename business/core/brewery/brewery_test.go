@@ -0,0 +1,101 @@
+package brewery_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/phbpx/gobeers/business/core/brewery"
+	"github.com/phbpx/gobeers/business/core/brewery/stores/brewerydb"
+	"github.com/phbpx/gobeers/business/data/dbtest"
+	"github.com/phbpx/gobeers/foundation/docker"
+)
+
+var c *docker.Container
+
+func TestMain(m *testing.M) {
+	var err error
+	c, err = dbtest.StartDB()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer dbtest.StopDB(c)
+
+	m.Run()
+}
+
+func TestBrewery(t *testing.T) {
+	log, db, teardown := dbtest.NewUnit(t, c, "testbrewery")
+	t.Cleanup(teardown)
+
+	core := brewery.NewCore(brewerydb.NewStore(log, db))
+
+	t.Log("Given the need to work with Brewery records.")
+	{
+		t.Logf("\tWhen handling a single Brewery.")
+		{
+			ctx := context.Background()
+			now := time.Date(2022, 2, 25, 0, 0, 0, 0, time.UTC)
+
+			nb := brewery.NewBrewery{
+				Name:        "Test Brewery",
+				Country:     "Brazil",
+				City:        "Blumenau",
+				Website:     "https://example.com",
+				FoundedYear: 1999,
+			}
+
+			b, err := core.Create(ctx, nb, now)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to add a brewery : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should be able to add a brewery.")
+
+			saved, err := core.QueryByID(ctx, b.ID)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to query a brewery by id : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should be able to query a brewery by id.")
+
+			if diff := cmp.Diff(b, saved); diff != "" {
+				t.Fatalf("\t [ERROR] Should get back the same brewery : %s", diff)
+			}
+			t.Logf("\t [SUCCESS] Should get back the same brewery.")
+
+			if _, err := core.Create(ctx, brewery.NewBrewery{Name: "test brewery"}, now); !errors.Is(err, brewery.ErrUniqueName) {
+				t.Fatalf("\t [ERROR] Should NOT be able to add a brewery with the same name : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should NOT be able to add a brewery with the same name.")
+
+			name := "Updated Brewery"
+			if _, err := core.Update(ctx, b.ID, brewery.UpdateBrewery{Name: &name}); err != nil {
+				t.Fatalf("\t [ERROR] Should be able to update a brewery : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should be able to update a brewery.")
+
+			saved, err = core.QueryByID(ctx, b.ID)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to query an updated brewery by id : %s", err)
+			}
+
+			if saved.Name != name || saved.City != nb.City {
+				t.Fatalf("\t [ERROR] Should get back the updated brewery : %+v", saved)
+			}
+			t.Logf("\t [SUCCESS] Should get back the updated brewery.")
+
+			if err := core.Delete(ctx, b.ID); err != nil {
+				t.Fatalf("\t [ERROR] Should be able to delete a brewery : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should be able to delete a brewery.")
+
+			if _, err := core.QueryByID(ctx, b.ID); !errors.Is(err, brewery.ErrNotFound) {
+				t.Fatalf("\t [ERROR] Should NOT be able to retrieve a deleted brewery : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should NOT be able to retrieve a deleted brewery.")
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package brewery
+
+import "time"
+
+// NewBrewery represents a new brewery to be added to the system.
+type NewBrewery struct {
+	Name        string `json:"name" validate:"required,max=255"`
+	Country     string `json:"country" validate:"max=100"`
+	City        string `json:"city" validate:"max=100"`
+	Website     string `json:"website" validate:"omitempty,url,max=255"`
+	FoundedYear int    `json:"founded_year" validate:"omitempty,min=1000,max=9999"`
+}
+
+// UpdateBrewery defines what information may be provided to modify an
+// existing brewery. All fields are optional so clients can send just the
+// fields they want changed.
+type UpdateBrewery struct {
+	Name        *string `json:"name" validate:"omitempty,min=1,max=255"`
+	Country     *string `json:"country" validate:"omitempty,max=100"`
+	City        *string `json:"city" validate:"omitempty,max=100"`
+	Website     *string `json:"website" validate:"omitempty,url,max=255"`
+	FoundedYear *int    `json:"founded_year" validate:"omitempty,min=1000,max=9999"`
+}
+
+// Brewery defines the properties of a brewery.
+type Brewery struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Country     string    `json:"country"`
+	City        string    `json:"city"`
+	Website     string    `json:"website"`
+	FoundedYear int       `json:"founded_year,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
@@ -0,0 +1,102 @@
+// Package brewerydb contains brewery related CRUD functionality.
+package brewerydb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/phbpx/gobeers/business/core/brewery"
+	"github.com/uptrace/bun"
+	"go.uber.org/zap"
+)
+
+// Store manages the set of APIs for brewery access.
+type Store struct {
+	log *zap.SugaredLogger
+	db  bun.IDB
+}
+
+// NewStore constructs a data for api access.
+func NewStore(log *zap.SugaredLogger, db *bun.DB) Store {
+	return Store{
+		log: log,
+		db:  db,
+	}
+}
+
+// AddBrewery adds a new brewery to the database.
+func (s Store) AddBrewery(ctx context.Context, b brewery.Brewery) error {
+	dbBrewery := toDBBrewery(b)
+
+	if _, err := s.db.NewInsert().Model(&dbBrewery).Exec(ctx); err != nil {
+		return fmt.Errorf("adding brewery: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateBrewery replaces a brewery document in the database.
+func (s Store) UpdateBrewery(ctx context.Context, b brewery.Brewery) error {
+	dbBrewery := toDBBrewery(b)
+
+	if _, err := s.db.NewUpdate().Model(&dbBrewery).WherePK().Exec(ctx); err != nil {
+		return fmt.Errorf("updating brewery [id=%s]: %w", b.ID, err)
+	}
+
+	return nil
+}
+
+// DeleteBrewery removes a brewery from the database. It returns sql.ErrNoRows
+// when there is no brewery with the provided id.
+func (s Store) DeleteBrewery(ctx context.Context, breweryID string) error {
+	res, err := s.db.NewDelete().
+		Model((*dbBrewery)(nil)).
+		Where("id = ?", breweryID).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("deleting brewery [id=%s]: %w", breweryID, err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("deleting brewery [id=%s]: %w", breweryID, err)
+	}
+	if n == 0 {
+		return fmt.Errorf("deleting brewery [id=%s]: %w", breweryID, sql.ErrNoRows)
+	}
+
+	return nil
+}
+
+// QueryBreweryByID retrieves a brewery by its id.
+func (s Store) QueryBreweryByID(ctx context.Context, breweryID string) (brewery.Brewery, error) {
+	var b dbBrewery
+
+	query := s.db.NewSelect().
+		Model(&b).
+		Where("id = ?", breweryID)
+
+	if err := query.Scan(ctx); err != nil {
+		return brewery.Brewery{}, fmt.Errorf("querying brewery by [id=%s]: %w", breweryID, err)
+	}
+
+	return toBrewery(b), nil
+}
+
+// QueryBreweries retrieves a list of existing breweries ordered by name.
+func (s Store) QueryBreweries(ctx context.Context, page int, size int) ([]brewery.Brewery, error) {
+	var breweries []dbBrewery
+
+	query := s.db.NewSelect().
+		Model(&breweries).
+		OrderExpr("br.name ASC, br.id ASC").
+		Limit(size).
+		Offset(size * (page - 1))
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, fmt.Errorf("querying breweries: %w", err)
+	}
+
+	return toBreweries(breweries), nil
+}
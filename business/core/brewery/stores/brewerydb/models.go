@@ -0,0 +1,55 @@
+package brewerydb
+
+import (
+	"time"
+
+	"github.com/phbpx/gobeers/business/core/brewery"
+	"github.com/uptrace/bun"
+)
+
+// dbBrewery represents an individual brewery.
+type dbBrewery struct {
+	bun.BaseModel `bun:"table:breweries,alias:br"`
+
+	ID          string    `bun:"id,pk"`
+	Name        string    `bun:"name"`
+	Country     string    `bun:"country"`
+	City        string    `bun:"city"`
+	Website     string    `bun:"website"`
+	FoundedYear int       `bun:"founded_year,nullzero"`
+	CreatedAt   time.Time `bun:"created_at"`
+}
+
+// =========================================================
+
+func toDBBrewery(b brewery.Brewery) dbBrewery {
+	return dbBrewery{
+		ID:          b.ID,
+		Name:        b.Name,
+		Country:     b.Country,
+		City:        b.City,
+		Website:     b.Website,
+		FoundedYear: b.FoundedYear,
+		CreatedAt:   b.CreatedAt,
+	}
+}
+
+func toBrewery(b dbBrewery) brewery.Brewery {
+	return brewery.Brewery{
+		ID:          b.ID,
+		Name:        b.Name,
+		Country:     b.Country,
+		City:        b.City,
+		Website:     b.Website,
+		FoundedYear: b.FoundedYear,
+		CreatedAt:   b.CreatedAt,
+	}
+}
+
+func toBreweries(list []dbBrewery) []brewery.Brewery {
+	breweries := make([]brewery.Brewery, len(list))
+	for i, b := range list {
+		breweries[i] = toBrewery(b)
+	}
+	return breweries
+}
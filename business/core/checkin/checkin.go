@@ -0,0 +1,141 @@
+// Package checkin provides the core business API for beer check-ins.
+package checkin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phbpx/gobeers/business/core/beer"
+	"github.com/phbpx/gobeers/business/sys/cursor"
+	"github.com/phbpx/gobeers/business/sys/order"
+	"github.com/phbpx/gobeers/business/sys/validate"
+)
+
+// Set of error variables for CRUD operations.
+var (
+	ErrInvalidID = errors.New("ID is not in its proper form")
+	ErrForbidden = errors.New("attempted action is not allowed")
+)
+
+// cursorTimeFormat is the layout used to store timestamps in a cursor. It
+// matches the microsecond precision of the database timestamps.
+const cursorTimeFormat = "2006-01-02 15:04:05.999999"
+
+// Storer interface declares the behavior this package needs to persist and
+// retrieve data.
+type Storer interface {
+	WithinTran(ctx context.Context, fn func(s Storer) error) error
+	AddCheckin(ctx context.Context, checkin Checkin) error
+	IncrementBeerCheckins(ctx context.Context, beerID string) error
+	QueryUserCheckinsByCursor(ctx context.Context, userID string, cur cursor.Cursor, limit int) ([]Checkin, error)
+}
+
+// Core manages the set of APIs for check-in access.
+type Core struct {
+	beer  beer.Core
+	store Storer
+}
+
+// NewCore constructs a core for check-in api access.
+func NewCore(beerCore beer.Core, store Storer) Core {
+	return Core{
+		beer:  beerCore,
+		store: store,
+	}
+}
+
+// Create records the user drinking a beer and updates the beer check-in
+// count. Its return the created Checkin with fields populated.
+func (c Core) Create(ctx context.Context, userID string, nc NewCheckin, now time.Time) (Checkin, error) {
+	if err := validate.CheckID(userID); err != nil {
+		return Checkin{}, ErrForbidden
+	}
+
+	if err := validate.Check(nc); err != nil {
+		return Checkin{}, fmt.Errorf("validating data: %w", err)
+	}
+
+	checkedInAt := now
+	if nc.CheckedInAt != nil {
+		if nc.CheckedInAt.After(now) {
+			return Checkin{}, validate.FieldErrors{{Field: "checked_in_at", Error: "checked_in_at can't be in the future"}}
+		}
+		checkedInAt = *nc.CheckedInAt
+	}
+
+	b, err := c.beer.QueryByID(ctx, nc.BeerID)
+	if err != nil {
+		if errors.Is(err, beer.ErrNotFound) {
+			return Checkin{}, validate.FieldErrors{{Field: "beer_id", Error: "beer_id does not exist"}}
+		}
+		return Checkin{}, fmt.Errorf("querying beer beerID[%s]: %w", nc.BeerID, err)
+	}
+
+	checkin := Checkin{
+		ID:          uuid.New().String(),
+		UserID:      userID,
+		BeerID:      b.ID,
+		Beer:        b.Name,
+		Brewery:     b.Brewery,
+		Venue:       nc.Venue,
+		Serving:     nc.Serving,
+		Rating:      nc.Rating,
+		CheckedInAt: checkedInAt.UTC(),
+		CreatedAt:   now,
+	}
+
+	tran := func(s Storer) error {
+		if err := s.AddCheckin(ctx, checkin); err != nil {
+			return fmt.Errorf("addCheckin: %w", err)
+		}
+		if err := s.IncrementBeerCheckins(ctx, b.ID); err != nil {
+			return fmt.Errorf("incrementBeerCheckins: %w", err)
+		}
+		return nil
+	}
+
+	if err := c.store.WithinTran(ctx, tran); err != nil {
+		return Checkin{}, fmt.Errorf("tran: %w", err)
+	}
+
+	return checkin, nil
+}
+
+// QueryTimelineByCursor gets a page of the check-ins of a user, the latest
+// first, positioned by the cursor.
+func (c Core) QueryTimelineByCursor(ctx context.Context, userID string, cur cursor.Cursor, size int) ([]Checkin, cursor.Page, error) {
+	if err := validate.CheckID(userID); err != nil {
+		return nil, cursor.Page{}, ErrInvalidID
+	}
+
+	if len(cur.OrderBy) != 1 || cur.OrderBy[0] != TimelineOrderBy {
+		return nil, cursor.Page{}, cursor.ErrInvalid
+	}
+
+	if cur.IsStart() && cur.Before || !cur.IsStart() && len(cur.Values) != 1 {
+		return nil, cursor.Page{}, cursor.ErrInvalid
+	}
+
+	if err := cursor.CheckSize(size); err != nil {
+		return nil, cursor.Page{}, err
+	}
+
+	// Fetch one extra check-in to know if there is a page past this one.
+	checkins, err := c.store.QueryUserCheckinsByCursor(ctx, userID, cur, size+1)
+	if err != nil {
+		return nil, cursor.Page{}, fmt.Errorf("queryUserCheckinsByCursor: %w", err)
+	}
+
+	checkins, page := cursor.Paginate(checkins, cur, size, timelineCursorValues)
+
+	return checkins, page, nil
+}
+
+// timelineCursorValues returns the sort key value and id of a check-in in a
+// user timeline.
+func timelineCursorValues(ci Checkin, _ []order.By) ([]string, string) {
+	return []string{ci.CheckedInAt.UTC().Format(cursorTimeFormat)}, ci.ID
+}
@@ -0,0 +1,149 @@
+package checkin_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phbpx/gobeers/business/core/beer"
+	"github.com/phbpx/gobeers/business/core/beer/stores/beerdb"
+	"github.com/phbpx/gobeers/business/core/brewery"
+	"github.com/phbpx/gobeers/business/core/brewery/stores/brewerydb"
+	"github.com/phbpx/gobeers/business/core/checkin"
+	"github.com/phbpx/gobeers/business/core/checkin/stores/checkindb"
+	"github.com/phbpx/gobeers/business/core/style"
+	"github.com/phbpx/gobeers/business/core/style/stores/styledb"
+	"github.com/phbpx/gobeers/business/data/dbtest"
+	"github.com/phbpx/gobeers/business/sys/blob/local"
+	"github.com/phbpx/gobeers/business/sys/cursor"
+	"github.com/phbpx/gobeers/business/sys/order"
+	"github.com/phbpx/gobeers/foundation/docker"
+)
+
+var c *docker.Container
+
+func TestMain(m *testing.M) {
+	var err error
+	c, err = dbtest.StartDB()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer dbtest.StopDB(c)
+
+	m.Run()
+}
+
+func TestCheckin(t *testing.T) {
+	log, db, teardown := dbtest.NewUnit(t, c, "testcheckin")
+	t.Cleanup(teardown)
+
+	breweryCore := brewery.NewCore(brewerydb.NewStore(log, db))
+	beerCore := beer.NewCore(breweryCore, style.NewCore(styledb.NewStore(log, db)), local.NewStore(t.TempDir(), "http://localhost/images"), beerdb.NewStore(log, db))
+	core := checkin.NewCore(beerCore, checkindb.NewStore(log, db))
+
+	t.Log("Given the need to work with Checkin records.")
+	{
+		t.Logf("\tWhen handling the timeline of a user.")
+		{
+			ctx := context.Background()
+			now := time.Date(2022, 2, 25, 0, 0, 0, 0, time.UTC)
+
+			brw, err := breweryCore.Create(ctx, brewery.NewBrewery{Name: "Test Brewery"}, now)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to add a brewery : %s", err)
+			}
+
+			nb := beer.NewBeer{
+				Name:      "Test Beer",
+				BreweryID: brw.ID,
+				Style:     "American IPA",
+				ABV:       5.5,
+				ShortDesc: "Test Short Description",
+			}
+
+			b, err := beerCore.Create(ctx, nb, false)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to add a beer : %s", err)
+			}
+
+			future := now.Add(time.Hour)
+			if _, err := core.Create(ctx, uuid.NewString(), checkin.NewCheckin{BeerID: b.ID, Serving: checkin.ServingCan, CheckedInAt: &future}, now); err == nil {
+				t.Fatalf("\t [ERROR] Should NOT be able to check in the future.")
+			}
+			t.Logf("\t [SUCCESS] Should NOT be able to check in the future.")
+
+			userID := uuid.NewString()
+			rating := float32(4.5)
+
+			var created []checkin.Checkin
+			for i, serving := range []string{checkin.ServingDraft, checkin.ServingBottle, checkin.ServingCan} {
+				at := now.Add(time.Duration(i-3) * time.Hour)
+
+				nc := checkin.NewCheckin{
+					BeerID:      b.ID,
+					Venue:       "Test Pub",
+					Serving:     serving,
+					Rating:      &rating,
+					CheckedInAt: &at,
+				}
+
+				ci, err := core.Create(ctx, userID, nc, now)
+				if err != nil {
+					t.Fatalf("\t [ERROR] Should be able to check in a beer : %s", err)
+				}
+				created = append(created, ci)
+			}
+			t.Logf("\t [SUCCESS] Should be able to check in a beer.")
+
+			saved, err := beerCore.QueryByID(ctx, b.ID)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to query a beer by id : %s", err)
+			}
+
+			if saved.CheckinCount != len(created) {
+				t.Fatalf("\t [ERROR] Should get back the beer check-in count : %d", saved.CheckinCount)
+			}
+			t.Logf("\t [SUCCESS] Should get back the beer check-in count.")
+
+			start := cursor.Cursor{OrderBy: []order.By{checkin.TimelineOrderBy}}
+
+			first, page, err := core.QueryTimelineByCursor(ctx, userID, start, 2)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to query the timeline : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should be able to query the timeline.")
+
+			if len(first) != 2 || first[0].ID != created[2].ID || first[0].Beer != nb.Name || page.Next == nil {
+				t.Fatalf("\t [ERROR] Should get back the latest check-ins first : %+v %+v", first, page)
+			}
+			t.Logf("\t [SUCCESS] Should get back the latest check-ins first.")
+
+			second, _, err := core.QueryTimelineByCursor(ctx, userID, *page.Next, 2)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to query the next page : %s", err)
+			}
+
+			if len(second) != 1 || second[0].ID != created[0].ID {
+				t.Fatalf("\t [ERROR] Should get back the oldest check-in last : %+v", second)
+			}
+			t.Logf("\t [SUCCESS] Should get back the oldest check-in last.")
+
+			if err := beerCore.Delete(ctx, b.ID); err != nil {
+				t.Fatalf("\t [ERROR] Should be able to delete a beer : %s", err)
+			}
+
+			deleted, _, err := core.QueryTimelineByCursor(ctx, userID, start, 2)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to query the timeline : %s", err)
+			}
+
+			if len(deleted) != 0 {
+				t.Fatalf("\t [ERROR] Should NOT get back the check-ins of a deleted beer : %+v", deleted)
+			}
+			t.Logf("\t [SUCCESS] Should NOT get back the check-ins of a deleted beer.")
+		}
+	}
+}
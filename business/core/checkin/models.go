@@ -0,0 +1,47 @@
+package checkin
+
+import (
+	"time"
+
+	"github.com/phbpx/gobeers/business/sys/order"
+)
+
+// Set of ways a beer can be served.
+const (
+	ServingDraft  = "draft"
+	ServingBottle = "bottle"
+	ServingCan    = "can"
+	ServingCask   = "cask"
+)
+
+// OrderByCheckedInAt is the field user timelines are ordered by.
+const OrderByCheckedInAt = "checked_in_at"
+
+// TimelineOrderBy is the ordering of user timelines, the latest check-ins
+// first.
+var TimelineOrderBy = order.NewBy(OrderByCheckedInAt, order.DESC)
+
+// NewCheckin contains information needed to check in a beer. CheckedInAt
+// defaults to the time the check-in is recorded.
+type NewCheckin struct {
+	BeerID      string     `json:"beer_id" validate:"required,uuid"`
+	Venue       string     `json:"venue" validate:"max=255"`
+	Serving     string     `json:"serving" validate:"required,oneof=draft bottle can cask"`
+	Rating      *float32   `json:"rating" validate:"omitempty,gt=0,lte=5"`
+	CheckedInAt *time.Time `json:"checked_in_at"`
+}
+
+// Checkin defines a user drinking a beer at a place and time. Beer and
+// Brewery are the names of the beer checked in and of its brewery.
+type Checkin struct {
+	ID          string    `json:"id"`
+	UserID      string    `json:"user_id"`
+	BeerID      string    `json:"beer_id"`
+	Beer        string    `json:"beer"`
+	Brewery     string    `json:"brewery"`
+	Venue       string    `json:"venue,omitempty"`
+	Serving     string    `json:"serving"`
+	Rating      *float32  `json:"rating,omitempty"`
+	CheckedInAt time.Time `json:"checked_in_at"`
+	CreatedAt   time.Time `json:"created_at"`
+}
@@ -0,0 +1,99 @@
+// Package checkindb contains check-in related CRUD functionality.
+package checkindb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/phbpx/gobeers/business/core/checkin"
+	"github.com/phbpx/gobeers/business/sys/cursor"
+	"github.com/uptrace/bun"
+	"go.uber.org/zap"
+)
+
+// Store manages the set of APIs for check-in access.
+type Store struct {
+	log *zap.SugaredLogger
+	db  bun.IDB
+}
+
+// NewStore constructs a data for api access.
+func NewStore(log *zap.SugaredLogger, db *bun.DB) Store {
+	return Store{
+		log: log,
+		db:  db,
+	}
+}
+
+// WithinTran runs passed function and do commit/rollback at the end.
+func (s Store) WithinTran(ctx context.Context, fn func(checkin.Storer) error) error {
+	return s.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		return fn(Store{log: s.log, db: &tx})
+	})
+}
+
+// AddCheckin adds a new check-in to the database.
+func (s Store) AddCheckin(ctx context.Context, c checkin.Checkin) error {
+	dbCheckin := toDBCheckin(c)
+
+	if _, err := s.db.NewInsert().Model(&dbCheckin).Exec(ctx); err != nil {
+		return fmt.Errorf("adding checkin: %w", err)
+	}
+
+	return nil
+}
+
+// IncrementBeerCheckins adds one to the check-in count kept with the beer
+// aggregates.
+func (s Store) IncrementBeerCheckins(ctx context.Context, beerID string) error {
+	const q = `
+	INSERT INTO beer_stats (beer_id, checkin_count)
+	VALUES (?, 1)
+	ON CONFLICT (beer_id) DO UPDATE SET
+		checkin_count = beer_stats.checkin_count + 1`
+
+	if _, err := s.db.ExecContext(ctx, q, beerID); err != nil {
+		return fmt.Errorf("incrementing beer checkins [id=%s]: %w", beerID, err)
+	}
+
+	return nil
+}
+
+// QueryUserCheckinsByCursor retrieves the check-ins of a user, the latest
+// first, positioned by the cursor. Check-ins of deleted beers are left out.
+func (s Store) QueryUserCheckinsByCursor(ctx context.Context, userID string, cur cursor.Cursor, limit int) ([]checkin.Checkin, error) {
+	var checkins []dbCheckin
+
+	query := s.db.NewSelect().
+		Model(&checkins).
+		ColumnExpr("?TableColumns").
+		ColumnExpr("b.name AS beer, b.brewery").
+		Join("JOIN beers AS b ON b.id = ci.beer_id AND b.deleted_at IS NULL").
+		Where("ci.user_id = ?", userID).
+		Limit(limit)
+
+	// Paging backwards walks the timeline in reverse and the page is put
+	// back in order once read.
+	switch {
+	case cur.Before:
+		query.Where("(ci.checked_in_at, ci.id) > (?, ?)", cur.Values[0], cur.ID).
+			OrderExpr("ci.checked_in_at ASC, ci.id ASC")
+	case !cur.IsStart():
+		query.Where("(ci.checked_in_at, ci.id) < (?, ?)", cur.Values[0], cur.ID).
+			OrderExpr("ci.checked_in_at DESC, ci.id DESC")
+	default:
+		query.OrderExpr("ci.checked_in_at DESC, ci.id DESC")
+	}
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, fmt.Errorf("querying checkins [user_id=%s]: %w", userID, err)
+	}
+
+	if cur.Before {
+		for i, j := 0, len(checkins)-1; i < j; i, j = i+1, j-1 {
+			checkins[i], checkins[j] = checkins[j], checkins[i]
+		}
+	}
+
+	return toCheckins(checkins), nil
+}
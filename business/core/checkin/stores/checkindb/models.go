@@ -0,0 +1,63 @@
+package checkindb
+
+import (
+	"time"
+
+	"github.com/phbpx/gobeers/business/core/checkin"
+	"github.com/uptrace/bun"
+)
+
+// dbCheckin represents a user drinking a beer at a place and time.
+type dbCheckin struct {
+	bun.BaseModel `bun:"table:checkins,alias:ci"`
+
+	ID          string    `bun:"id,pk"`
+	UserID      string    `bun:"user_id"`
+	BeerID      string    `bun:"beer_id"`
+	Venue       string    `bun:"venue,nullzero"`
+	Serving     string    `bun:"serving"`
+	Rating      *float32  `bun:"rating"`
+	CheckedInAt time.Time `bun:"checked_in_at"`
+	CreatedAt   time.Time `bun:"created_at"`
+
+	Beer    string `bun:"beer,scanonly"`
+	Brewery string `bun:"brewery,scanonly"`
+}
+
+// =========================================================
+
+func toDBCheckin(c checkin.Checkin) dbCheckin {
+	return dbCheckin{
+		ID:          c.ID,
+		UserID:      c.UserID,
+		BeerID:      c.BeerID,
+		Venue:       c.Venue,
+		Serving:     c.Serving,
+		Rating:      c.Rating,
+		CheckedInAt: c.CheckedInAt,
+		CreatedAt:   c.CreatedAt,
+	}
+}
+
+func toCheckin(c dbCheckin) checkin.Checkin {
+	return checkin.Checkin{
+		ID:          c.ID,
+		UserID:      c.UserID,
+		BeerID:      c.BeerID,
+		Beer:        c.Beer,
+		Brewery:     c.Brewery,
+		Venue:       c.Venue,
+		Serving:     c.Serving,
+		Rating:      c.Rating,
+		CheckedInAt: c.CheckedInAt,
+		CreatedAt:   c.CreatedAt,
+	}
+}
+
+func toCheckins(list []dbCheckin) []checkin.Checkin {
+	checkins := make([]checkin.Checkin, len(list))
+	for i, c := range list {
+		checkins[i] = toCheckin(c)
+	}
+	return checkins
+}
@@ -0,0 +1,25 @@
+package recommendation
+
+import "github.com/phbpx/gobeers/business/core/beer"
+
+// Set of reasons a beer is recommended.
+const (
+	ReasonSimilar = "similar"
+	ReasonPopular = "popular"
+)
+
+// Candidate is a beer considered for a recommendation along with the score
+// used to rank it.
+type Candidate struct {
+	BeerID string
+	Score  float32
+}
+
+// Recommendation is a beer recommended to a user. Reason tells whether it
+// was picked for being similar to beers the user reviewed or for being
+// popular in the styles the user likes.
+type Recommendation struct {
+	Beer   beer.Beer `json:"beer"`
+	Score  float32   `json:"score"`
+	Reason string    `json:"reason"`
+}
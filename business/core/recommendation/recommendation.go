@@ -0,0 +1,149 @@
+// Package recommendation provides the core business API for personalized
+// beer recommendations. Recommendations come from item to item collaborative
+// filtering over the published reviews: beers reviewed by the same users are
+// similar, and the beers most similar to the ones a user rated are
+// recommended to the user.
+package recommendation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/phbpx/gobeers/business/core/beer"
+	"github.com/phbpx/gobeers/business/sys/validate"
+)
+
+// ErrForbidden is returned when the caller isn't a known user.
+var ErrForbidden = errors.New("attempted action is not allowed")
+
+// Tuning for the similarity computation and the recommendations.
+const (
+	// minCommonUsers is the number of users that must have reviewed both
+	// beers for their similarity to be trusted.
+	minCommonUsers = 2
+
+	// maxSimilarPerBeer caps the similar beers kept for every beer.
+	maxSimilarPerBeer = 50
+
+	// likedScore is the average score from which a user is considered to
+	// like a style.
+	likedScore = 4
+
+	// MaxLimit is the maximum number of recommendations returned at once.
+	MaxLimit = 50
+)
+
+// Storer interface declares the behavior this package needs to persist and
+// retrieve data.
+type Storer interface {
+	RebuildSimilarities(ctx context.Context, minCommonUsers int, maxPerBeer int, now time.Time) error
+	QuerySimilarToReviewed(ctx context.Context, userID string, limit int) ([]Candidate, error)
+	QueryLikedStyles(ctx context.Context, userID string, minScore float32) ([]string, error)
+	QueryPopularUnreviewed(ctx context.Context, userID string, styles []string, limit int) ([]Candidate, error)
+}
+
+// Core manages the set of APIs for recommendation access.
+type Core struct {
+	beer  beer.Core
+	store Storer
+}
+
+// NewCore constructs a core for recommendation api access.
+func NewCore(beerCore beer.Core, store Storer) Core {
+	return Core{
+		beer:  beerCore,
+		store: store,
+	}
+}
+
+// RefreshSimilarities recomputes the similarity between beers from the
+// published reviews. It's meant to run periodically.
+func (c Core) RefreshSimilarities(ctx context.Context, now time.Time) error {
+	if err := c.store.RebuildSimilarities(ctx, minCommonUsers, maxSimilarPerBeer, now); err != nil {
+		return fmt.Errorf("rebuildSimilarities: %w", err)
+	}
+
+	return nil
+}
+
+// Query gets the beers recommended to the user, best first. Beers the user
+// already reviewed are never recommended. When there isn't enough review
+// history to find similar beers, the list is completed with popular beers in
+// the styles the user rated highly, or in any style for users without
+// reviews.
+func (c Core) Query(ctx context.Context, userID string, limit int) ([]Recommendation, error) {
+	if err := validate.CheckID(userID); err != nil {
+		return nil, ErrForbidden
+	}
+
+	if limit <= 0 || limit > MaxLimit {
+		return nil, validate.FieldErrors{{Field: "limit", Error: fmt.Sprintf("limit must be between 1 and %d", MaxLimit)}}
+	}
+
+	similar, err := c.store.QuerySimilarToReviewed(ctx, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("querySimilarToReviewed: %w", err)
+	}
+
+	reasons := make(map[string]string, limit)
+	candidates := make([]Candidate, 0, limit)
+	for _, cand := range similar {
+		reasons[cand.BeerID] = ReasonSimilar
+		candidates = append(candidates, cand)
+	}
+
+	if len(candidates) < limit {
+		styles, err := c.store.QueryLikedStyles(ctx, userID, likedScore)
+		if err != nil {
+			return nil, fmt.Errorf("queryLikedStyles: %w", err)
+		}
+
+		// Ask for enough popular beers to fill the list even when some of
+		// them were already recommended as similar.
+		popular, err := c.store.QueryPopularUnreviewed(ctx, userID, styles, limit)
+		if err != nil {
+			return nil, fmt.Errorf("queryPopularUnreviewed: %w", err)
+		}
+
+		for _, cand := range popular {
+			if len(candidates) == limit {
+				break
+			}
+			if _, exists := reasons[cand.BeerID]; exists {
+				continue
+			}
+			reasons[cand.BeerID] = ReasonPopular
+			candidates = append(candidates, cand)
+		}
+	}
+
+	return c.toRecommendations(ctx, candidates, reasons)
+}
+
+// toRecommendations loads the beers of the candidates, keeping their order.
+func (c Core) toRecommendations(ctx context.Context, candidates []Candidate, reasons map[string]string) ([]Recommendation, error) {
+	ids := make([]string, len(candidates))
+	scores := make(map[string]float32, len(candidates))
+	for i, cand := range candidates {
+		ids[i] = cand.BeerID
+		scores[cand.BeerID] = cand.Score
+	}
+
+	beers, err := c.beer.QueryByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("querying beers: %w", err)
+	}
+
+	recs := make([]Recommendation, len(beers))
+	for i, b := range beers {
+		recs[i] = Recommendation{
+			Beer:   b,
+			Score:  scores[b.ID],
+			Reason: reasons[b.ID],
+		}
+	}
+
+	return recs, nil
+}
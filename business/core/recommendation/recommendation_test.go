@@ -0,0 +1,131 @@
+package recommendation_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phbpx/gobeers/business/core/beer"
+	"github.com/phbpx/gobeers/business/core/beer/stores/beerdb"
+	"github.com/phbpx/gobeers/business/core/brewery"
+	"github.com/phbpx/gobeers/business/core/brewery/stores/brewerydb"
+	"github.com/phbpx/gobeers/business/core/recommendation"
+	"github.com/phbpx/gobeers/business/core/recommendation/stores/recommendationdb"
+	"github.com/phbpx/gobeers/business/core/style"
+	"github.com/phbpx/gobeers/business/core/style/stores/styledb"
+	"github.com/phbpx/gobeers/business/data/dbtest"
+	"github.com/phbpx/gobeers/business/sys/blob/local"
+	"github.com/phbpx/gobeers/foundation/docker"
+)
+
+var c *docker.Container
+
+func TestMain(m *testing.M) {
+	var err error
+	c, err = dbtest.StartDB()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer dbtest.StopDB(c)
+
+	m.Run()
+}
+
+func TestRecommendation(t *testing.T) {
+	log, db, teardown := dbtest.NewUnit(t, c, "testrecommendation")
+	t.Cleanup(teardown)
+
+	breweryCore := brewery.NewCore(brewerydb.NewStore(log, db))
+	beerCore := beer.NewCore(breweryCore, style.NewCore(styledb.NewStore(log, db)), local.NewStore(t.TempDir(), "http://localhost/images"), beerdb.NewStore(log, db))
+	core := recommendation.NewCore(beerCore, recommendationdb.NewStore(log, db))
+
+	t.Log("Given the need to recommend beers to users.")
+	{
+		t.Logf("\tWhen handling the review history of users.")
+		{
+			ctx := context.Background()
+			now := time.Date(2022, 2, 25, 0, 0, 0, 0, time.UTC)
+
+			brw, err := breweryCore.Create(ctx, brewery.NewBrewery{Name: "Test Brewery"}, now)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to add a brewery : %s", err)
+			}
+
+			var beers []beer.Beer
+			for _, name := range []string{"Beer A", "Beer B", "Beer C"} {
+				nb := beer.NewBeer{
+					Name:      name,
+					BreweryID: brw.ID,
+					Style:     "American IPA",
+					ABV:       5.5,
+					ShortDesc: "Test Short Description",
+				}
+
+				b, err := beerCore.Create(ctx, nb, false)
+				if err != nil {
+					t.Fatalf("\t [ERROR] Should be able to add a beer : %s", err)
+				}
+				beers = append(beers, b)
+			}
+			a, b, other := beers[0], beers[1], beers[2]
+
+			review := func(userID string, beerID string, score float32) {
+				nr := beer.NewReview{Score: score, Comment: "Test Comment"}
+				if _, err := beerCore.CreateReview(ctx, userID, beerID, nr, false, now); err != nil {
+					t.Fatalf("\t [ERROR] Should be able to review a beer : %s", err)
+				}
+			}
+
+			// Two users liked both A and B, so they're similar.
+			for i := 0; i < 2; i++ {
+				userID := uuid.NewString()
+				review(userID, a.ID, 5)
+				review(userID, b.ID, 5)
+			}
+			review(uuid.NewString(), other.ID, 3)
+
+			userID := uuid.NewString()
+			review(userID, a.ID, 5)
+			t.Logf("\t [SUCCESS] Should be able to review beers.")
+
+			if err := core.RefreshSimilarities(ctx, now); err != nil {
+				t.Fatalf("\t [ERROR] Should be able to refresh the similarities : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should be able to refresh the similarities.")
+
+			recs, err := core.Query(ctx, userID, 10)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to query the recommendations : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should be able to query the recommendations.")
+
+			if len(recs) != 2 || recs[0].Beer.ID != b.ID || recs[0].Reason != recommendation.ReasonSimilar {
+				t.Fatalf("\t [ERROR] Should get back a beer similar to the reviewed ones first : %+v", recs)
+			}
+			t.Logf("\t [SUCCESS] Should get back a beer similar to the reviewed ones first.")
+
+			if recs[1].Beer.ID != other.ID || recs[1].Reason != recommendation.ReasonPopular {
+				t.Fatalf("\t [ERROR] Should get back a popular beer in a liked style : %+v", recs[1])
+			}
+			t.Logf("\t [SUCCESS] Should get back a popular beer in a liked style.")
+
+			cold, err := core.Query(ctx, uuid.NewString(), 10)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to query the recommendations of a new user : %s", err)
+			}
+
+			if len(cold) != len(beers) || cold[0].Beer.ID != a.ID || cold[0].Reason != recommendation.ReasonPopular {
+				t.Fatalf("\t [ERROR] Should get back the popular beers for a new user : %+v", cold)
+			}
+			t.Logf("\t [SUCCESS] Should get back the popular beers for a new user.")
+
+			if _, err := core.Query(ctx, userID, recommendation.MaxLimit+1); err == nil {
+				t.Fatalf("\t [ERROR] Should NOT be able to query more than %d recommendations.", recommendation.MaxLimit)
+			}
+			t.Logf("\t [SUCCESS] Should NOT be able to query more than %d recommendations.", recommendation.MaxLimit)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package recommendationdb
+
+import "github.com/phbpx/gobeers/business/core/recommendation"
+
+// dbCandidate represents a beer considered for a recommendation.
+type dbCandidate struct {
+	BeerID string  `bun:"beer_id"`
+	Score  float32 `bun:"score"`
+}
+
+// =========================================================
+
+func toCandidates(list []dbCandidate) []recommendation.Candidate {
+	candidates := make([]recommendation.Candidate, len(list))
+	for i, c := range list {
+		candidates[i] = recommendation.Candidate{
+			BeerID: c.BeerID,
+			Score:  c.Score,
+		}
+	}
+	return candidates
+}
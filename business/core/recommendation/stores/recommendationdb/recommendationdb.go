@@ -0,0 +1,154 @@
+// Package recommendationdb contains recommendation related functionality.
+package recommendationdb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/phbpx/gobeers/business/core/beer"
+	"github.com/phbpx/gobeers/business/core/recommendation"
+	"github.com/uptrace/bun"
+	"go.uber.org/zap"
+)
+
+// Store manages the set of APIs for recommendation access.
+type Store struct {
+	log *zap.SugaredLogger
+	db  bun.IDB
+}
+
+// NewStore constructs a data for api access.
+func NewStore(log *zap.SugaredLogger, db *bun.DB) Store {
+	return Store{
+		log: log,
+		db:  db,
+	}
+}
+
+// RebuildSimilarities replaces the beer similarities with the ones computed
+// from the published reviews. The similarity of two beers is the cosine of
+// the vectors of the scores users gave them, kept only for pairs reviewed by
+// at least minCommonUsers users and for the maxPerBeer most similar beers of
+// every beer. Readers keep seeing the previous similarities until the
+// rebuild commits.
+func (s Store) RebuildSimilarities(ctx context.Context, minCommonUsers int, maxPerBeer int, now time.Time) error {
+	const q = `
+	WITH r AS (
+		SELECT beer_id, user_id, score
+		FROM reviews
+		WHERE status = ? AND deleted_at IS NULL
+	), norms AS (
+		SELECT beer_id, SQRT(SUM(score * score)) AS norm
+		FROM r
+		GROUP BY beer_id
+	), pairs AS (
+		SELECT a.beer_id, b.beer_id AS similar_beer_id, SUM(a.score * b.score) AS dot, COUNT(*) AS common_users
+		FROM r AS a
+		JOIN r AS b ON b.user_id = a.user_id AND b.beer_id <> a.beer_id
+		GROUP BY a.beer_id, b.beer_id
+		HAVING COUNT(*) >= ?
+	), ranked AS (
+		SELECT
+			p.beer_id,
+			p.similar_beer_id,
+			p.dot / (na.norm * nb.norm) AS score,
+			p.common_users,
+			ROW_NUMBER() OVER (PARTITION BY p.beer_id ORDER BY p.dot / (na.norm * nb.norm) DESC, p.similar_beer_id) AS rank
+		FROM pairs AS p
+		JOIN norms AS na ON na.beer_id = p.beer_id
+		JOIN norms AS nb ON nb.beer_id = p.similar_beer_id
+		WHERE na.norm > 0 AND nb.norm > 0
+	)
+	INSERT INTO beer_similarities (beer_id, similar_beer_id, score, common_users, computed_at)
+	SELECT beer_id, similar_beer_id, score, common_users, ?
+	FROM ranked
+	WHERE rank <= ?`
+
+	return s.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM beer_similarities"); err != nil {
+			return fmt.Errorf("deleting beer similarities: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, q, beer.ReviewPublished, minCommonUsers, now, maxPerBeer); err != nil {
+			return fmt.Errorf("computing beer similarities: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// QuerySimilarToReviewed retrieves the beers similar to the ones the user
+// reviewed, excluding the reviewed ones. Every beer is scored with the
+// rating the user is predicted to give it: the average of the user ratings
+// weighted by their similarity to the beer.
+func (s Store) QuerySimilarToReviewed(ctx context.Context, userID string, limit int) ([]recommendation.Candidate, error) {
+	const q = `
+	SELECT s.similar_beer_id AS beer_id, (SUM(s.score * r.score) / SUM(s.score))::real AS score
+	FROM reviews AS r
+	JOIN beer_similarities AS s ON s.beer_id = r.beer_id
+	JOIN beers AS b ON b.id = s.similar_beer_id AND b.deleted_at IS NULL
+	WHERE r.user_id = ? AND r.status = ? AND r.deleted_at IS NULL
+		AND NOT EXISTS (
+			SELECT 1 FROM reviews AS mine
+			WHERE mine.user_id = r.user_id AND mine.beer_id = s.similar_beer_id
+		)
+	GROUP BY s.similar_beer_id
+	ORDER BY score DESC, SUM(s.score) DESC, s.similar_beer_id
+	LIMIT ?`
+
+	var candidates []dbCandidate
+	if err := s.db.NewRaw(q, userID, beer.ReviewPublished, limit).Scan(ctx, &candidates); err != nil {
+		return nil, fmt.Errorf("querying similar beers [user_id=%s]: %w", userID, err)
+	}
+
+	return toCandidates(candidates), nil
+}
+
+// QueryLikedStyles retrieves the styles the user gave an average score of at
+// least minScore, the best rated first.
+func (s Store) QueryLikedStyles(ctx context.Context, userID string, minScore float32) ([]string, error) {
+	const q = `
+	SELECT b.style
+	FROM reviews AS r
+	JOIN beers AS b ON b.id = r.beer_id AND b.deleted_at IS NULL
+	WHERE r.user_id = ? AND r.status = ? AND r.deleted_at IS NULL
+	GROUP BY b.style
+	HAVING AVG(r.score) >= ?
+	ORDER BY AVG(r.score) DESC, b.style`
+
+	var styles []string
+	if err := s.db.NewRaw(q, userID, beer.ReviewPublished, minScore).Scan(ctx, &styles); err != nil {
+		return nil, fmt.Errorf("querying liked styles [user_id=%s]: %w", userID, err)
+	}
+
+	return styles, nil
+}
+
+// QueryPopularUnreviewed retrieves the most reviewed beers the user didn't
+// review yet, scored by their average score. When styles is not empty only
+// beers in those styles are considered.
+func (s Store) QueryPopularUnreviewed(ctx context.Context, userID string, styles []string, limit int) ([]recommendation.Candidate, error) {
+	var candidates []dbCandidate
+
+	query := s.db.NewSelect().
+		TableExpr("beers AS b").
+		ColumnExpr("b.id AS beer_id").
+		ColumnExpr("bs.avg_score::real AS score").
+		Join("JOIN beer_stats AS bs ON bs.beer_id = b.id").
+		Where("b.deleted_at IS NULL").
+		Where("bs.review_count > 0").
+		Where("NOT EXISTS (SELECT 1 FROM reviews AS r WHERE r.user_id = ? AND r.beer_id = b.id)", userID).
+		OrderExpr("bs.review_count DESC, bs.avg_score DESC, b.id ASC").
+		Limit(limit)
+
+	if len(styles) > 0 {
+		query.Where("b.style IN (?)", bun.In(styles))
+	}
+
+	if err := query.Scan(ctx, &candidates); err != nil {
+		return nil, fmt.Errorf("querying popular beers [user_id=%s]: %w", userID, err)
+	}
+
+	return toCandidates(candidates), nil
+}
@@ -0,0 +1,36 @@
+package style
+
+// Set of levels in the style taxonomy.
+const (
+	LevelCategory = "category"
+	LevelStyle    = "style"
+	LevelSubstyle = "substyle"
+)
+
+// Range defines the minimum and maximum values of a vital statistic.
+type Range struct {
+	Min float32 `json:"min"`
+	Max float32 `json:"max"`
+}
+
+// Vitals defines the vital statistics ranges of a style: alcohol by volume,
+// bitterness, color and original and final gravity.
+type Vitals struct {
+	ABV Range `json:"abv"`
+	IBU Range `json:"ibu"`
+	SRM Range `json:"srm"`
+	OG  Range `json:"og"`
+	FG  Range `json:"fg"`
+}
+
+// Style defines the properties of an entry in the style taxonomy. The ID is
+// the BJCP code, for example "21A". Categories, and styles that depend on
+// their substyles, have no vital statistics.
+type Style struct {
+	ID       string  `json:"id"`
+	ParentID string  `json:"parent_id,omitempty"`
+	Level    string  `json:"level"`
+	Name     string  `json:"name"`
+	Vitals   *Vitals `json:"vitals,omitempty"`
+	Children []Style `json:"children,omitempty"`
+}
@@ -0,0 +1,66 @@
+package styledb
+
+import (
+	"database/sql"
+
+	"github.com/phbpx/gobeers/business/core/style"
+	"github.com/uptrace/bun"
+)
+
+// dbStyle represents an entry in the style taxonomy.
+type dbStyle struct {
+	bun.BaseModel `bun:"table:styles,alias:s"`
+
+	ID       string          `bun:"id,pk"`
+	ParentID sql.NullString  `bun:"parent_id"`
+	Level    string          `bun:"level"`
+	Name     string          `bun:"name"`
+	ABVMin   sql.NullFloat64 `bun:"abv_min"`
+	ABVMax   sql.NullFloat64 `bun:"abv_max"`
+	IBUMin   sql.NullFloat64 `bun:"ibu_min"`
+	IBUMax   sql.NullFloat64 `bun:"ibu_max"`
+	SRMMin   sql.NullFloat64 `bun:"srm_min"`
+	SRMMax   sql.NullFloat64 `bun:"srm_max"`
+	OGMin    sql.NullFloat64 `bun:"og_min"`
+	OGMax    sql.NullFloat64 `bun:"og_max"`
+	FGMin    sql.NullFloat64 `bun:"fg_min"`
+	FGMax    sql.NullFloat64 `bun:"fg_max"`
+}
+
+// =========================================================
+
+func toStyle(s dbStyle) style.Style {
+	st := style.Style{
+		ID:       s.ID,
+		ParentID: s.ParentID.String,
+		Level:    s.Level,
+		Name:     s.Name,
+	}
+
+	if s.ABVMin.Valid {
+		st.Vitals = &style.Vitals{
+			ABV: toRange(s.ABVMin, s.ABVMax),
+			IBU: toRange(s.IBUMin, s.IBUMax),
+			SRM: toRange(s.SRMMin, s.SRMMax),
+			OG:  toRange(s.OGMin, s.OGMax),
+			FG:  toRange(s.FGMin, s.FGMax),
+		}
+	}
+
+	return st
+}
+
+func toStyles(list []dbStyle) []style.Style {
+	styles := make([]style.Style, len(list))
+	for i, s := range list {
+		styles[i] = toStyle(s)
+	}
+	return styles
+}
+
+func toRange(min sql.NullFloat64, max sql.NullFloat64) style.Range {
+	return style.Range{
+		Min: float32(min.Float64),
+		Max: float32(max.Float64),
+	}
+}
@@ -0,0 +1,71 @@
+// Package styledb contains style taxonomy related functionality.
+package styledb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/phbpx/gobeers/business/core/style"
+	"github.com/uptrace/bun"
+	"go.uber.org/zap"
+)
+
+// Store manages the set of APIs for style access.
+type Store struct {
+	log *zap.SugaredLogger
+	db  bun.IDB
+}
+
+// NewStore constructs a data for api access.
+func NewStore(log *zap.SugaredLogger, db *bun.DB) Store {
+	return Store{
+		log: log,
+		db:  db,
+	}
+}
+
+// QueryStyles retrieves the full taxonomy ordered by BJCP code.
+func (s Store) QueryStyles(ctx context.Context) ([]style.Style, error) {
+	var styles []dbStyle
+
+	query := s.db.NewSelect().
+		Model(&styles).
+		OrderExpr("substring(s.id from '^[0-9]+')::int ASC, s.id ASC")
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, fmt.Errorf("querying styles: %w", err)
+	}
+
+	return toStyles(styles), nil
+}
+
+// QueryStyleByID retrieves a style by its BJCP code.
+func (s Store) QueryStyleByID(ctx context.Context, styleID string) (style.Style, error) {
+	var st dbStyle
+
+	query := s.db.NewSelect().
+		Model(&st).
+		Where("id = ?", styleID)
+
+	if err := query.Scan(ctx); err != nil {
+		return style.Style{}, fmt.Errorf("querying style by [id=%s]: %w", styleID, err)
+	}
+
+	return toStyle(st), nil
+}
+
+// QueryStyleByName retrieves a style or substyle by its name, ignoring case.
+func (s Store) QueryStyleByName(ctx context.Context, name string) (style.Style, error) {
+	var st dbStyle
+
+	query := s.db.NewSelect().
+		Model(&st).
+		Where("LOWER(name) = LOWER(?)", name).
+		Where("level <> ?", style.LevelCategory)
+
+	if err := query.Scan(ctx); err != nil {
+		return style.Style{}, fmt.Errorf("querying style by [name=%s]: %w", name, err)
+	}
+
+	return toStyle(st), nil
+}
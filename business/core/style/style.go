@@ -0,0 +1,95 @@
+// Package style provides the core business API for the beer style taxonomy,
+// based on the BJCP guidelines.
+package style
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/phbpx/gobeers/business/sys/database"
+)
+
+// Set of error variables for CRUD operations.
+var (
+	ErrNotFound = errors.New("style not found")
+)
+
+// Storer interface declares the behavior this package needs to retrieve data.
+type Storer interface {
+	QueryStyles(ctx context.Context) ([]Style, error)
+	QueryStyleByID(ctx context.Context, styleID string) (Style, error)
+	QueryStyleByName(ctx context.Context, name string) (Style, error)
+}
+
+// Core manages the set of APIs for style access.
+type Core struct {
+	store Storer
+}
+
+// NewCore constructs a core for style api access.
+func NewCore(store Storer) Core {
+	return Core{
+		store: store,
+	}
+}
+
+// Query gets the full taxonomy as a tree of categories, with their styles
+// and substyles as children.
+func (c Core) Query(ctx context.Context) ([]Style, error) {
+	styles, err := c.store.QueryStyles(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("queryStyles: %w", err)
+	}
+
+	return buildTree(styles, ""), nil
+}
+
+// QueryByID gets the specified style, along with its children, from the
+// database.
+func (c Core) QueryByID(ctx context.Context, styleID string) (Style, error) {
+	style, err := c.store.QueryStyleByID(ctx, strings.ToUpper(styleID))
+	if err != nil {
+		if database.IsNoRowError(err) {
+			return Style{}, ErrNotFound
+		}
+		return Style{}, fmt.Errorf("queryStyleByID: %w", err)
+	}
+
+	styles, err := c.store.QueryStyles(ctx)
+	if err != nil {
+		return Style{}, fmt.Errorf("queryStyles: %w", err)
+	}
+
+	style.Children = buildTree(styles, style.ID)
+
+	return style, nil
+}
+
+// QueryByName gets the style or substyle with the specified name, ignoring
+// case. Categories are not beer styles so they are never returned.
+func (c Core) QueryByName(ctx context.Context, name string) (Style, error) {
+	style, err := c.store.QueryStyleByName(ctx, strings.TrimSpace(name))
+	if err != nil {
+		if database.IsNoRowError(err) {
+			return Style{}, ErrNotFound
+		}
+		return Style{}, fmt.Errorf("queryStyleByName: %w", err)
+	}
+
+	return style, nil
+}
+
+// buildTree nests the styles under their parents, returning the children of
+// the provided parent.
+func buildTree(styles []Style, parentID string) []Style {
+	var children []Style
+	for _, s := range styles {
+		if s.ParentID == parentID {
+			s.Children = buildTree(styles, s.ID)
+			children = append(children, s)
+		}
+	}
+	return children
+}
@@ -0,0 +1,87 @@
+package style_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/phbpx/gobeers/business/core/style"
+	"github.com/phbpx/gobeers/business/core/style/stores/styledb"
+	"github.com/phbpx/gobeers/business/data/dbtest"
+	"github.com/phbpx/gobeers/foundation/docker"
+)
+
+var c *docker.Container
+
+func TestMain(m *testing.M) {
+	var err error
+	c, err = dbtest.StartDB()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer dbtest.StopDB(c)
+
+	m.Run()
+}
+
+func TestStyle(t *testing.T) {
+	log, db, teardown := dbtest.NewUnit(t, c, "teststyle")
+	t.Cleanup(teardown)
+
+	core := style.NewCore(styledb.NewStore(log, db))
+
+	t.Log("Given the need to work with the Style taxonomy.")
+	{
+		t.Logf("\tWhen handling the seeded BJCP styles.")
+		{
+			ctx := context.Background()
+
+			categories, err := core.Query(ctx)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to query the taxonomy : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should be able to query the taxonomy.")
+
+			if len(categories) == 0 || categories[0].Level != style.LevelCategory || len(categories[0].Children) == 0 {
+				t.Fatalf("\t [ERROR] Should get back categories with their styles : %+v", categories)
+			}
+			t.Logf("\t [SUCCESS] Should get back categories with their styles.")
+
+			specialty, err := core.QueryByID(ctx, "21b")
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to query a style by id : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should be able to query a style by id.")
+
+			if specialty.Vitals != nil || len(specialty.Children) == 0 {
+				t.Fatalf("\t [ERROR] Should get back the substyles of a style : %+v", specialty)
+			}
+			t.Logf("\t [SUCCESS] Should get back the substyles of a style.")
+
+			ipa, err := core.QueryByName(ctx, "american ipa")
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to query a style by name : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should be able to query a style by name.")
+
+			if ipa.ID != "21A" || ipa.Vitals == nil || ipa.Vitals.ABV.Min != 5.5 {
+				t.Fatalf("\t [ERROR] Should get back the style vital statistics : %+v", ipa)
+			}
+			t.Logf("\t [SUCCESS] Should get back the style vital statistics.")
+
+			for _, name := range []string{"Hazy IPA", "Kölsch", "Belgian Single", "Brut IPA"} {
+				if _, err := core.QueryByName(ctx, name); err != nil {
+					t.Fatalf("\t [ERROR] Should be able to query a BJCP 2021 style, name[%s] : %s", name, err)
+				}
+			}
+			t.Logf("\t [SUCCESS] Should be able to query the BJCP 2021 styles.")
+
+			if _, err := core.QueryByName(ctx, "IPA"); !errors.Is(err, style.ErrNotFound) {
+				t.Fatalf("\t [ERROR] Should NOT be able to query a category as a style : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should NOT be able to query a category as a style.")
+		}
+	}
+}
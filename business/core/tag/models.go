@@ -0,0 +1,31 @@
+package tag
+
+import "time"
+
+// NewTag represents a new curated tag to be added to the system.
+type NewTag struct {
+	Name string `json:"name" validate:"required,max=50"`
+}
+
+// UpdateTag defines what information may be provided to modify an existing
+// tag. Setting Curated promotes a free-form tag to a curated one, or the
+// other way around.
+type UpdateTag struct {
+	Name    *string `json:"name" validate:"omitempty,min=1,max=50"`
+	Curated *bool   `json:"curated"`
+}
+
+// Tag defines the properties of a tag. BeerCount is the number of beers
+// labelled with the tag.
+type Tag struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Curated   bool      `json:"curated"`
+	BeerCount int       `json:"beer_count"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// QueryFilter holds the available fields a query can be filtered on.
+type QueryFilter struct {
+	Curated *bool
+}
@@ -0,0 +1,49 @@
+package tagdb
+
+import (
+	"time"
+
+	"github.com/phbpx/gobeers/business/core/tag"
+	"github.com/uptrace/bun"
+)
+
+// dbTag represents an individual tag.
+type dbTag struct {
+	bun.BaseModel `bun:"table:tags,alias:t"`
+
+	ID        string    `bun:"id,pk"`
+	Name      string    `bun:"name"`
+	Curated   bool      `bun:"curated"`
+	CreatedAt time.Time `bun:"created_at"`
+
+	BeerCount int `bun:"beer_count,scanonly"`
+}
+
+// =========================================================
+
+func toDBTag(t tag.Tag) dbTag {
+	return dbTag{
+		ID:        t.ID,
+		Name:      t.Name,
+		Curated:   t.Curated,
+		CreatedAt: t.CreatedAt,
+	}
+}
+
+func toTag(t dbTag) tag.Tag {
+	return tag.Tag{
+		ID:        t.ID,
+		Name:      t.Name,
+		Curated:   t.Curated,
+		BeerCount: t.BeerCount,
+		CreatedAt: t.CreatedAt,
+	}
+}
+
+func toTags(list []dbTag) []tag.Tag {
+	tags := make([]tag.Tag, len(list))
+	for i, t := range list {
+		tags[i] = toTag(t)
+	}
+	return tags
+}
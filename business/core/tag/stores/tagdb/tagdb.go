@@ -0,0 +1,137 @@
+// Package tagdb contains tag related CRUD functionality.
+package tagdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/phbpx/gobeers/business/core/tag"
+	"github.com/uptrace/bun"
+	"go.uber.org/zap"
+)
+
+// Store manages the set of APIs for tag access.
+type Store struct {
+	log *zap.SugaredLogger
+	db  bun.IDB
+}
+
+// NewStore constructs a data for api access.
+func NewStore(log *zap.SugaredLogger, db *bun.DB) Store {
+	return Store{
+		log: log,
+		db:  db,
+	}
+}
+
+// AddTag adds a new tag to the database.
+func (s Store) AddTag(ctx context.Context, t tag.Tag) error {
+	dbTag := toDBTag(t)
+
+	if _, err := s.db.NewInsert().Model(&dbTag).Exec(ctx); err != nil {
+		return fmt.Errorf("adding tag: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateTag replaces a tag document in the database.
+func (s Store) UpdateTag(ctx context.Context, t tag.Tag) error {
+	dbTag := toDBTag(t)
+
+	if _, err := s.db.NewUpdate().Model(&dbTag).WherePK().Exec(ctx); err != nil {
+		return fmt.Errorf("updating tag [id=%s]: %w", t.ID, err)
+	}
+
+	return nil
+}
+
+// DeleteTag removes a tag from the database. It returns sql.ErrNoRows when
+// there is no tag with the provided id.
+func (s Store) DeleteTag(ctx context.Context, tagID string) error {
+	res, err := s.db.NewDelete().
+		Model((*dbTag)(nil)).
+		Where("id = ?", tagID).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("deleting tag [id=%s]: %w", tagID, err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("deleting tag [id=%s]: %w", tagID, err)
+	}
+	if n == 0 {
+		return fmt.Errorf("deleting tag [id=%s]: %w", tagID, sql.ErrNoRows)
+	}
+
+	return nil
+}
+
+// QueryTagByID retrieves a tag by its id.
+func (s Store) QueryTagByID(ctx context.Context, tagID string) (tag.Tag, error) {
+	var t dbTag
+
+	query := s.selectTags(&t).
+		Where("t.id = ?", tagID)
+
+	if err := query.Scan(ctx); err != nil {
+		return tag.Tag{}, fmt.Errorf("querying tag by [id=%s]: %w", tagID, err)
+	}
+
+	return toTag(t), nil
+}
+
+// QueryTags retrieves a list of existing tags that match the filter ordered
+// by name.
+func (s Store) QueryTags(ctx context.Context, filter tag.QueryFilter, page int, size int) ([]tag.Tag, error) {
+	var tags []dbTag
+
+	query := s.selectTags(&tags).
+		OrderExpr("t.name ASC").
+		Limit(size).
+		Offset(size * (page - 1))
+
+	if filter.Curated != nil {
+		query.Where("t.curated = ?", *filter.Curated)
+	}
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, fmt.Errorf("querying tags: %w", err)
+	}
+
+	return toTags(tags), nil
+}
+
+// QueryCloud retrieves the tags labelling at least one beer, the most used
+// first.
+func (s Store) QueryCloud(ctx context.Context, limit int) ([]tag.Tag, error) {
+	var tags []dbTag
+
+	query := s.db.NewSelect().
+		Model(&tags).
+		ColumnExpr("?TableColumns").
+		ColumnExpr("COUNT(*) AS beer_count").
+		Join("JOIN beer_tags AS bt ON bt.tag_id = t.id").
+		Join("JOIN beers AS b ON b.id = bt.beer_id AND b.deleted_at IS NULL").
+		GroupExpr("t.id").
+		OrderExpr("beer_count DESC").
+		OrderExpr("t.name ASC").
+		Limit(limit)
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, fmt.Errorf("querying tag cloud: %w", err)
+	}
+
+	return toTags(tags), nil
+}
+
+// selectTags builds the base query used to read tags along with the number
+// of beers they label.
+func (s Store) selectTags(model any) *bun.SelectQuery {
+	return s.db.NewSelect().
+		Model(model).
+		ColumnExpr("?TableColumns").
+		ColumnExpr("(SELECT COUNT(*) FROM beer_tags AS bt JOIN beers AS b ON b.id = bt.beer_id AND b.deleted_at IS NULL WHERE bt.tag_id = t.id) AS beer_count")
+}
@@ -0,0 +1,178 @@
+// Package tag provides the core business API for the tags labelling beers.
+// Tags are either curated, created by moderators and admins, or free-form,
+// created on the fly when a beer is tagged with an unknown name.
+package tag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phbpx/gobeers/business/sys/database"
+	"github.com/phbpx/gobeers/business/sys/validate"
+)
+
+// Set of error variables for CRUD operations.
+var (
+	ErrNotFound   = errors.New("tag not found")
+	ErrInvalidID  = errors.New("ID is not in its proper form")
+	ErrUniqueName = errors.New("tag name already exists")
+)
+
+// maxCloudLimit is the maximum number of tags in a tag cloud.
+const maxCloudLimit = 100
+
+// Storer interface declares the behavior this package needs to persist and
+// retrieve data.
+type Storer interface {
+	AddTag(ctx context.Context, tag Tag) error
+	UpdateTag(ctx context.Context, tag Tag) error
+	DeleteTag(ctx context.Context, tagID string) error
+	QueryTagByID(ctx context.Context, tagID string) (Tag, error)
+	QueryTags(ctx context.Context, filter QueryFilter, page int, size int) ([]Tag, error)
+	QueryCloud(ctx context.Context, limit int) ([]Tag, error)
+}
+
+// Core manages the set of APIs for tag access.
+type Core struct {
+	store Storer
+}
+
+// NewCore constructs a core for tag api access.
+func NewCore(store Storer) Core {
+	return Core{
+		store: store,
+	}
+}
+
+// Create adds a curated tag to the database. Its return the created Tag
+// with fields populated.
+func (c Core) Create(ctx context.Context, nt NewTag, now time.Time) (Tag, error) {
+	nt.Name = Normalize(nt.Name)
+
+	if err := validate.Check(nt); err != nil {
+		return Tag{}, fmt.Errorf("validating data: %w", err)
+	}
+
+	tag := Tag{
+		ID:        uuid.New().String(),
+		Name:      nt.Name,
+		Curated:   true,
+		CreatedAt: now,
+	}
+
+	if err := c.store.AddTag(ctx, tag); err != nil {
+		if database.IsIntegrityViolation(err) {
+			return Tag{}, ErrUniqueName
+		}
+		return Tag{}, fmt.Errorf("addTag: %w", err)
+	}
+
+	return tag, nil
+}
+
+// Update replaces the fields of a tag that are set in the UpdateTag value,
+// renaming the tag on every beer it labels. Its return the updated Tag.
+func (c Core) Update(ctx context.Context, tagID string, ut UpdateTag) (Tag, error) {
+	if err := validate.CheckID(tagID); err != nil {
+		return Tag{}, ErrInvalidID
+	}
+
+	if ut.Name != nil {
+		name := Normalize(*ut.Name)
+		ut.Name = &name
+	}
+
+	if err := validate.Check(ut); err != nil {
+		return Tag{}, fmt.Errorf("validating data: %w", err)
+	}
+
+	tag, err := c.QueryByID(ctx, tagID)
+	if err != nil {
+		return Tag{}, err
+	}
+
+	if ut.Name != nil {
+		tag.Name = *ut.Name
+	}
+	if ut.Curated != nil {
+		tag.Curated = *ut.Curated
+	}
+
+	if err := c.store.UpdateTag(ctx, tag); err != nil {
+		if database.IsIntegrityViolation(err) {
+			return Tag{}, ErrUniqueName
+		}
+		return Tag{}, fmt.Errorf("updateTag: %w", err)
+	}
+
+	return tag, nil
+}
+
+// Delete removes the specified tag from the database, untagging the beers
+// it labels.
+func (c Core) Delete(ctx context.Context, tagID string) error {
+	if err := validate.CheckID(tagID); err != nil {
+		return ErrInvalidID
+	}
+
+	if err := c.store.DeleteTag(ctx, tagID); err != nil {
+		if database.IsNoRowError(err) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("deleteTag: %w", err)
+	}
+
+	return nil
+}
+
+// QueryByID gets the specified tag from the database.
+func (c Core) QueryByID(ctx context.Context, tagID string) (Tag, error) {
+	if err := validate.CheckID(tagID); err != nil {
+		return Tag{}, ErrInvalidID
+	}
+
+	tag, err := c.store.QueryTagByID(ctx, tagID)
+	if err != nil {
+		if database.IsNoRowError(err) {
+			return Tag{}, ErrNotFound
+		}
+		return Tag{}, fmt.Errorf("queryTagByID: %w", err)
+	}
+
+	return tag, nil
+}
+
+// Query gets the tags that match the filter, ordered by name.
+func (c Core) Query(ctx context.Context, filter QueryFilter, page int, size int) ([]Tag, error) {
+	tags, err := c.store.QueryTags(ctx, filter, page, size)
+	if err != nil {
+		return nil, fmt.Errorf("queryTags: %w", err)
+	}
+
+	return tags, nil
+}
+
+// QueryCloud gets the tags labelling at least one beer, the most used
+// first, to render a tag cloud.
+func (c Core) QueryCloud(ctx context.Context, limit int) ([]Tag, error) {
+	if limit < 1 || limit > maxCloudLimit {
+		return nil, validate.FieldErrors{{Field: "limit", Error: fmt.Sprintf("limit must be between 1 and %d", maxCloudLimit)}}
+	}
+
+	tags, err := c.store.QueryCloud(ctx, limit)
+	if err != nil {
+		return nil, fmt.Errorf("queryCloud: %w", err)
+	}
+
+	return tags, nil
+}
+
+// Normalize returns the canonical form of a tag name: lower case words
+// joined by dashes, so "Barrel Aged" and "barrel-aged" are the same tag.
+func Normalize(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), "-")
+}
@@ -0,0 +1,105 @@
+package tag_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/phbpx/gobeers/business/core/tag"
+	"github.com/phbpx/gobeers/business/core/tag/stores/tagdb"
+	"github.com/phbpx/gobeers/business/data/dbtest"
+	"github.com/phbpx/gobeers/foundation/docker"
+)
+
+var c *docker.Container
+
+func TestMain(m *testing.M) {
+	var err error
+	c, err = dbtest.StartDB()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer dbtest.StopDB(c)
+
+	m.Run()
+}
+
+func TestTag(t *testing.T) {
+	log, db, teardown := dbtest.NewUnit(t, c, "testtag")
+	t.Cleanup(teardown)
+
+	core := tag.NewCore(tagdb.NewStore(log, db))
+
+	t.Log("Given the need to work with Tag records.")
+	{
+		t.Logf("\tWhen handling a single Tag.")
+		{
+			ctx := context.Background()
+			now := time.Date(2022, 2, 25, 0, 0, 0, 0, time.UTC)
+
+			tg, err := core.Create(ctx, tag.NewTag{Name: " Barrel  Aged "}, now)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to add a tag : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should be able to add a tag.")
+
+			if tg.Name != "barrel-aged" || !tg.Curated {
+				t.Fatalf("\t [ERROR] Should add a curated tag with a normalized name : %+v", tg)
+			}
+			t.Logf("\t [SUCCESS] Should add a curated tag with a normalized name.")
+
+			saved, err := core.QueryByID(ctx, tg.ID)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to query a tag by id : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should be able to query a tag by id.")
+
+			if diff := cmp.Diff(tg, saved); diff != "" {
+				t.Fatalf("\t [ERROR] Should get back the same tag : %s", diff)
+			}
+			t.Logf("\t [SUCCESS] Should get back the same tag.")
+
+			if _, err := core.Create(ctx, tag.NewTag{Name: "barrel-aged"}, now); !errors.Is(err, tag.ErrUniqueName) {
+				t.Fatalf("\t [ERROR] Should NOT be able to add a tag with the same name : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should NOT be able to add a tag with the same name.")
+
+			name := "Sour"
+			curated := false
+			updated, err := core.Update(ctx, tg.ID, tag.UpdateTag{Name: &name, Curated: &curated})
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to update a tag : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should be able to update a tag.")
+
+			if updated.Name != "sour" || updated.Curated {
+				t.Fatalf("\t [ERROR] Should get back the updated tag : %+v", updated)
+			}
+			t.Logf("\t [SUCCESS] Should get back the updated tag.")
+
+			tags, err := core.Query(ctx, tag.QueryFilter{Curated: &curated}, 1, 10)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to query the free-form tags : %s", err)
+			}
+
+			if len(tags) != 1 || tags[0].ID != tg.ID {
+				t.Fatalf("\t [ERROR] Should get back the free-form tags : %+v", tags)
+			}
+			t.Logf("\t [SUCCESS] Should get back the free-form tags.")
+
+			if err := core.Delete(ctx, tg.ID); err != nil {
+				t.Fatalf("\t [ERROR] Should be able to delete a tag : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should be able to delete a tag.")
+
+			if _, err := core.QueryByID(ctx, tg.ID); !errors.Is(err, tag.ErrNotFound) {
+				t.Fatalf("\t [ERROR] Should NOT be able to retrieve a deleted tag : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should NOT be able to retrieve a deleted tag.")
+		}
+	}
+}
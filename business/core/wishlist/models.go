@@ -0,0 +1,16 @@
+package wishlist
+
+import "time"
+
+// Item defines a beer saved by a user to try later. When the user has
+// reviewed the beer, ReviewID and ReviewedAt reference the review.
+type Item struct {
+	BeerID     string     `json:"beer_id"`
+	Name       string     `json:"name"`
+	Brewery    string     `json:"brewery"`
+	Style      string     `json:"style"`
+	Reviewed   bool       `json:"reviewed"`
+	ReviewID   string     `json:"review_id,omitempty"`
+	ReviewedAt *time.Time `json:"reviewed_at,omitempty"`
+	AddedAt    time.Time  `json:"added_at"`
+}
@@ -0,0 +1,48 @@
+package wishlistdb
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/phbpx/gobeers/business/core/wishlist"
+	"github.com/uptrace/bun"
+)
+
+// dbItem represents a beer in the wishlist of a user, along with the beer
+// details and the review of the user, if any.
+type dbItem struct {
+	bun.BaseModel `bun:"table:wishlist_items,alias:wi"`
+
+	UserID    string    `bun:"user_id,pk"`
+	BeerID    string    `bun:"beer_id,pk"`
+	CreatedAt time.Time `bun:"created_at"`
+
+	Name       string         `bun:"name,scanonly"`
+	Brewery    string         `bun:"brewery,scanonly"`
+	Style      string         `bun:"style,scanonly"`
+	ReviewID   sql.NullString `bun:"review_id,scanonly"`
+	ReviewedAt *time.Time     `bun:"reviewed_at,scanonly"`
+}
+
+// =========================================================
+
+func toItem(i dbItem) wishlist.Item {
+	return wishlist.Item{
+		BeerID:     i.BeerID,
+		Name:       i.Name,
+		Brewery:    i.Brewery,
+		Style:      i.Style,
+		Reviewed:   i.ReviewID.Valid,
+		ReviewID:   i.ReviewID.String,
+		ReviewedAt: i.ReviewedAt,
+		AddedAt:    i.CreatedAt,
+	}
+}
+
+func toItems(list []dbItem) []wishlist.Item {
+	items := make([]wishlist.Item, len(list))
+	for i, item := range list {
+		items[i] = toItem(item)
+	}
+	return items
+}
@@ -0,0 +1,114 @@
+// Package wishlistdb contains wishlist related CRUD functionality.
+package wishlistdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/phbpx/gobeers/business/core/wishlist"
+	"github.com/uptrace/bun"
+	"go.uber.org/zap"
+)
+
+// Store manages the set of APIs for wishlist access.
+type Store struct {
+	log *zap.SugaredLogger
+	db  bun.IDB
+}
+
+// NewStore constructs a data for api access.
+func NewStore(log *zap.SugaredLogger, db *bun.DB) Store {
+	return Store{
+		log: log,
+		db:  db,
+	}
+}
+
+// AddItem adds a beer to the wishlist of a user. Adding a beer that is
+// already in the wishlist is a no-op.
+func (s Store) AddItem(ctx context.Context, userID string, beerID string, now time.Time) error {
+	item := dbItem{
+		UserID:    userID,
+		BeerID:    beerID,
+		CreatedAt: now,
+	}
+
+	query := s.db.NewInsert().
+		Model(&item).
+		On("CONFLICT (user_id, beer_id) DO NOTHING")
+
+	if _, err := query.Exec(ctx); err != nil {
+		return fmt.Errorf("adding wishlist item [user_id=%s, beer_id=%s]: %w", userID, beerID, err)
+	}
+
+	return nil
+}
+
+// DeleteItem removes a beer from the wishlist of a user. It returns
+// sql.ErrNoRows when the beer is not in the wishlist.
+func (s Store) DeleteItem(ctx context.Context, userID string, beerID string) error {
+	res, err := s.db.NewDelete().
+		Model((*dbItem)(nil)).
+		Where("user_id = ?", userID).
+		Where("beer_id = ?", beerID).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("deleting wishlist item [user_id=%s, beer_id=%s]: %w", userID, beerID, err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("deleting wishlist item [user_id=%s, beer_id=%s]: %w", userID, beerID, err)
+	}
+	if n == 0 {
+		return fmt.Errorf("deleting wishlist item [user_id=%s, beer_id=%s]: %w", userID, beerID, sql.ErrNoRows)
+	}
+
+	return nil
+}
+
+// QueryItem retrieves a beer from the wishlist of a user.
+func (s Store) QueryItem(ctx context.Context, userID string, beerID string) (wishlist.Item, error) {
+	var item dbItem
+
+	query := s.selectItems(&item, userID).
+		Where("wi.beer_id = ?", beerID)
+
+	if err := query.Scan(ctx); err != nil {
+		return wishlist.Item{}, fmt.Errorf("querying wishlist item [user_id=%s, beer_id=%s]: %w", userID, beerID, err)
+	}
+
+	return toItem(item), nil
+}
+
+// QueryItems retrieves the wishlist of a user, the most recently added beers
+// first.
+func (s Store) QueryItems(ctx context.Context, userID string, page int, size int) ([]wishlist.Item, error) {
+	var items []dbItem
+
+	query := s.selectItems(&items, userID).
+		OrderExpr("wi.created_at DESC, wi.beer_id ASC").
+		Limit(size).
+		Offset(size * (page - 1))
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, fmt.Errorf("querying wishlist [user_id=%s]: %w", userID, err)
+	}
+
+	return toItems(items), nil
+}
+
+// selectItems builds the base query used to read the wishlist of a user
+// along with the beer details and the review of the user, if any.
+func (s Store) selectItems(model any, userID string) *bun.SelectQuery {
+	return s.db.NewSelect().
+		Model(model).
+		ColumnExpr("?TableColumns").
+		ColumnExpr("b.name, b.brewery, b.style").
+		ColumnExpr("r.id AS review_id, r.created_at AS reviewed_at").
+		Join("JOIN beers AS b ON b.id = wi.beer_id AND b.deleted_at IS NULL").
+		Join("LEFT JOIN reviews AS r ON r.beer_id = wi.beer_id AND r.user_id = wi.user_id AND r.deleted_at IS NULL").
+		Where("wi.user_id = ?", userID)
+}
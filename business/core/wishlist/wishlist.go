@@ -0,0 +1,127 @@
+// Package wishlist provides the core business API for the beers users want
+// to try.
+package wishlist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/phbpx/gobeers/business/core/beer"
+	"github.com/phbpx/gobeers/business/sys/database"
+	"github.com/phbpx/gobeers/business/sys/validate"
+)
+
+// Set of error variables for CRUD operations.
+var (
+	ErrNotFound     = errors.New("beer not in wishlist")
+	ErrBeerNotFound = errors.New("beer not found")
+	ErrInvalidID    = errors.New("ID is not in its proper form")
+	ErrForbidden    = errors.New("attempted action is not allowed")
+)
+
+// Storer interface declares the behavior this package needs to persist and
+// retrieve data.
+type Storer interface {
+	AddItem(ctx context.Context, userID string, beerID string, now time.Time) error
+	DeleteItem(ctx context.Context, userID string, beerID string) error
+	QueryItem(ctx context.Context, userID string, beerID string) (Item, error)
+	QueryItems(ctx context.Context, userID string, page int, size int) ([]Item, error)
+}
+
+// Core manages the set of APIs for wishlist access.
+type Core struct {
+	beer  beer.Core
+	store Storer
+}
+
+// NewCore constructs a core for wishlist api access.
+func NewCore(beerCore beer.Core, store Storer) Core {
+	return Core{
+		beer:  beerCore,
+		store: store,
+	}
+}
+
+// Add saves a beer to the wishlist of the user. Adding a beer that is already
+// in the wishlist keeps the date it was first added. Its return the saved
+// Item.
+func (c Core) Add(ctx context.Context, userID string, beerID string, now time.Time) (Item, error) {
+	if err := checkIDs(userID, beerID); err != nil {
+		return Item{}, err
+	}
+
+	if _, err := c.beer.QueryByID(ctx, beerID); err != nil {
+		if errors.Is(err, beer.ErrNotFound) {
+			return Item{}, ErrBeerNotFound
+		}
+		return Item{}, fmt.Errorf("querying beer beerID[%s]: %w", beerID, err)
+	}
+
+	if err := c.store.AddItem(ctx, userID, beerID, now); err != nil {
+		return Item{}, fmt.Errorf("addItem: %w", err)
+	}
+
+	return c.QueryByID(ctx, userID, beerID)
+}
+
+// Remove deletes a beer from the wishlist of the user.
+func (c Core) Remove(ctx context.Context, userID string, beerID string) error {
+	if err := checkIDs(userID, beerID); err != nil {
+		return err
+	}
+
+	if err := c.store.DeleteItem(ctx, userID, beerID); err != nil {
+		if database.IsNoRowError(err) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("deleteItem: %w", err)
+	}
+
+	return nil
+}
+
+// QueryByID gets a beer from the wishlist of the user.
+func (c Core) QueryByID(ctx context.Context, userID string, beerID string) (Item, error) {
+	if err := checkIDs(userID, beerID); err != nil {
+		return Item{}, err
+	}
+
+	item, err := c.store.QueryItem(ctx, userID, beerID)
+	if err != nil {
+		if database.IsNoRowError(err) {
+			return Item{}, ErrNotFound
+		}
+		return Item{}, fmt.Errorf("queryItem: %w", err)
+	}
+
+	return item, nil
+}
+
+// Query gets the wishlist of the user, the most recently added beers first.
+func (c Core) Query(ctx context.Context, userID string, page int, size int) ([]Item, error) {
+	if err := validate.CheckID(userID); err != nil {
+		return nil, ErrForbidden
+	}
+
+	items, err := c.store.QueryItems(ctx, userID, page, size)
+	if err != nil {
+		return nil, fmt.Errorf("queryItems: %w", err)
+	}
+
+	return items, nil
+}
+
+// checkIDs validates the user and beer ids of a wishlist item.
+func checkIDs(userID string, beerID string) error {
+	if err := validate.CheckID(userID); err != nil {
+		return ErrForbidden
+	}
+
+	if err := validate.CheckID(beerID); err != nil {
+		return ErrInvalidID
+	}
+
+	return nil
+}
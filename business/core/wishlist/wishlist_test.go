@@ -0,0 +1,121 @@
+package wishlist_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/phbpx/gobeers/business/core/beer"
+	"github.com/phbpx/gobeers/business/core/beer/stores/beerdb"
+	"github.com/phbpx/gobeers/business/core/brewery"
+	"github.com/phbpx/gobeers/business/core/brewery/stores/brewerydb"
+	"github.com/phbpx/gobeers/business/core/style"
+	"github.com/phbpx/gobeers/business/core/style/stores/styledb"
+	"github.com/phbpx/gobeers/business/core/wishlist"
+	"github.com/phbpx/gobeers/business/core/wishlist/stores/wishlistdb"
+	"github.com/phbpx/gobeers/business/data/dbtest"
+	"github.com/phbpx/gobeers/business/sys/blob/local"
+	"github.com/phbpx/gobeers/foundation/docker"
+)
+
+var c *docker.Container
+
+func TestMain(m *testing.M) {
+	var err error
+	c, err = dbtest.StartDB()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer dbtest.StopDB(c)
+
+	m.Run()
+}
+
+func TestWishlist(t *testing.T) {
+	log, db, teardown := dbtest.NewUnit(t, c, "testwishlist")
+	t.Cleanup(teardown)
+
+	breweryCore := brewery.NewCore(brewerydb.NewStore(log, db))
+	beerCore := beer.NewCore(breweryCore, style.NewCore(styledb.NewStore(log, db)), local.NewStore(t.TempDir(), "http://localhost/images"), beerdb.NewStore(log, db))
+	core := wishlist.NewCore(beerCore, wishlistdb.NewStore(log, db))
+
+	t.Log("Given the need to work with Wishlist records.")
+	{
+		t.Logf("\tWhen handling a single Wishlist item.")
+		{
+			ctx := context.Background()
+			now := time.Date(2022, 2, 25, 0, 0, 0, 0, time.UTC)
+
+			brw, err := breweryCore.Create(ctx, brewery.NewBrewery{Name: "Test Brewery"}, now)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to add a brewery : %s", err)
+			}
+
+			nb := beer.NewBeer{
+				Name:      "Test Beer",
+				BreweryID: brw.ID,
+				Style:     "American IPA",
+				ABV:       5.5,
+				ShortDesc: "Test Short Description",
+			}
+
+			b, err := beerCore.Create(ctx, nb, false)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to add a beer : %s", err)
+			}
+
+			userID := uuid.NewString()
+
+			if _, err := core.Add(ctx, userID, uuid.NewString(), now); !errors.Is(err, wishlist.ErrBeerNotFound) {
+				t.Fatalf("\t [ERROR] Should NOT be able to add an unknown beer : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should NOT be able to add an unknown beer.")
+
+			item, err := core.Add(ctx, userID, b.ID, now)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to add a beer to the wishlist : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should be able to add a beer to the wishlist.")
+
+			if item.Name != nb.Name || item.Reviewed {
+				t.Fatalf("\t [ERROR] Should get back the unreviewed beer : %+v", item)
+			}
+			t.Logf("\t [SUCCESS] Should get back the unreviewed beer.")
+
+			if _, err := core.Add(ctx, userID, b.ID, now.Add(time.Hour)); err != nil {
+				t.Fatalf("\t [ERROR] Should be able to add a beer twice : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should be able to add a beer twice.")
+
+			rw, err := beerCore.CreateReview(ctx, userID, b.ID, beer.NewReview{Score: 4, Comment: "Test Comment"}, false, now)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to add a review : %s", err)
+			}
+
+			items, err := core.Query(ctx, userID, 1, 10)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to query the wishlist : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should be able to query the wishlist.")
+
+			if len(items) != 1 || !items[0].Reviewed || items[0].ReviewID != rw.ID || !items[0].AddedAt.Equal(now) {
+				t.Fatalf("\t [ERROR] Should get back the reviewed beer : %+v", items)
+			}
+			t.Logf("\t [SUCCESS] Should get back the reviewed beer.")
+
+			if err := core.Remove(ctx, userID, b.ID); err != nil {
+				t.Fatalf("\t [ERROR] Should be able to remove a beer from the wishlist : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should be able to remove a beer from the wishlist.")
+
+			if _, err := core.QueryByID(ctx, userID, b.ID); !errors.Is(err, wishlist.ErrNotFound) {
+				t.Fatalf("\t [ERROR] Should NOT be able to retrieve a removed beer : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should NOT be able to retrieve a removed beer.")
+		}
+	}
+}
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS "beer_stats" (
+    "beer_id" UUID PRIMARY KEY REFERENCES "beers" ("id") ON DELETE CASCADE,
+    "review_count" INT NOT NULL DEFAULT 0,
+    "avg_score" FLOAT NOT NULL DEFAULT 0,
+    "last_reviewed_at" TIMESTAMP NULL
+);
+
+INSERT INTO "beer_stats" ("beer_id", "review_count", "avg_score", "last_reviewed_at")
+SELECT "beer_id", COUNT(*), AVG("score"), MAX("created_at")
+FROM "reviews"
+GROUP BY "beer_id";
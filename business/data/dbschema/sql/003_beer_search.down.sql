@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS "beers_search_vector_idx";
+ALTER TABLE "beers" DROP COLUMN IF EXISTS "search_vector";
@@ -0,0 +1,8 @@
+ALTER TABLE "beers" ADD COLUMN IF NOT EXISTS "search_vector" TSVECTOR
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', coalesce("name", '')), 'A') ||
+        setweight(to_tsvector('english', coalesce("brewery", '')), 'B') ||
+        setweight(to_tsvector('english', coalesce("short_desc", '')), 'C')
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS "beers_search_vector_idx" ON "beers" USING GIN ("search_vector");
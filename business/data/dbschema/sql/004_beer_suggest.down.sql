@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS "beers_brewery_trgm_idx";
+DROP INDEX IF EXISTS "beers_name_trgm_idx";
@@ -0,0 +1,4 @@
+CREATE EXTENSION IF NOT EXISTS "pg_trgm";
+
+CREATE INDEX IF NOT EXISTS "beers_name_trgm_idx" ON "beers" USING GIN ("name" gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS "beers_brewery_trgm_idx" ON "beers" USING GIN ("brewery" gin_trgm_ops);
@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS "breweries_sync_beer_name" ON "breweries";
+DROP FUNCTION IF EXISTS "sync_beer_brewery_name";
+DROP INDEX IF EXISTS "beers_brewery_id_idx";
+ALTER TABLE "beers" DROP COLUMN IF EXISTS "brewery_id";
+DROP TABLE IF EXISTS "breweries";
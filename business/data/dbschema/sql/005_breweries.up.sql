@@ -0,0 +1,44 @@
+CREATE TABLE IF NOT EXISTS "breweries" (
+    "id" UUID PRIMARY KEY,
+    "created_at" TIMESTAMP NOT NULL,
+    "name" VARCHAR(255) NOT NULL,
+    "country" VARCHAR(100) NOT NULL DEFAULT '',
+    "city" VARCHAR(100) NOT NULL DEFAULT '',
+    "website" VARCHAR(255) NOT NULL DEFAULT '',
+    "founded_year" INT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "breweries_name_idx" ON "breweries" (LOWER("name"));
+
+-- Backfill the breweries from the free text brewery names of the existing
+-- beers, merging names that only differ by case or surrounding spaces.
+INSERT INTO "breweries" ("id", "created_at", "name")
+SELECT gen_random_uuid(), NOW() AT TIME ZONE 'utc', TRIM(MIN("brewery"))
+FROM "beers"
+GROUP BY LOWER(TRIM("brewery"));
+
+ALTER TABLE "beers" ADD COLUMN IF NOT EXISTS "brewery_id" UUID REFERENCES "breweries" ("id");
+
+UPDATE "beers" AS b
+SET "brewery_id" = br."id", "brewery" = br."name"
+FROM "breweries" AS br
+WHERE LOWER(TRIM(b."brewery")) = LOWER(br."name");
+
+ALTER TABLE "beers" ALTER COLUMN "brewery_id" SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS "beers_brewery_id_idx" ON "beers" ("brewery_id");
+
+-- The beers keep a copy of the brewery name so it can be searched and
+-- filtered on without a join. Keep it in sync when a brewery is renamed.
+CREATE OR REPLACE FUNCTION "sync_beer_brewery_name"() RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE "beers" SET "brewery" = NEW."name" WHERE "brewery_id" = NEW."id";
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "breweries_sync_beer_name"
+    AFTER UPDATE OF "name" ON "breweries"
+    FOR EACH ROW
+    WHEN (OLD."name" IS DISTINCT FROM NEW."name")
+    EXECUTE FUNCTION "sync_beer_brewery_name"();
@@ -0,0 +1,2 @@
+DROP VIEW IF EXISTS "beers_unknown_style";
+DROP TABLE IF EXISTS "styles";
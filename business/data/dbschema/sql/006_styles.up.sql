@@ -0,0 +1,234 @@
+CREATE TABLE IF NOT EXISTS "styles" (
+    "id" VARCHAR(32) PRIMARY KEY,
+    "parent_id" VARCHAR(32) NULL REFERENCES "styles" ("id") ON DELETE CASCADE,
+    "level" VARCHAR(16) NOT NULL CHECK ("level" IN ('category', 'style', 'substyle')),
+    "name" VARCHAR(255) NOT NULL,
+    "abv_min" FLOAT NULL,
+    "abv_max" FLOAT NULL,
+    "ibu_min" FLOAT NULL,
+    "ibu_max" FLOAT NULL,
+    "srm_min" FLOAT NULL,
+    "srm_max" FLOAT NULL,
+    "og_min" FLOAT NULL,
+    "og_max" FLOAT NULL,
+    "fg_min" FLOAT NULL,
+    "fg_max" FLOAT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "styles_name_idx" ON "styles" (LOWER("name"));
+CREATE INDEX IF NOT EXISTS "styles_parent_id_idx" ON "styles" ("parent_id");
+
+-- Seed the taxonomy with the BJCP 2021 guidelines.
+-- https://www.bjcp.org/style/2021/beer/
+INSERT INTO "styles" ("id", "parent_id", "level", "name") VALUES
+    ('1', NULL, 'category', 'Standard American Beer'),
+    ('2', NULL, 'category', 'International Lager'),
+    ('3', NULL, 'category', 'Czech Lager'),
+    ('4', NULL, 'category', 'Pale Malty European Lager'),
+    ('5', NULL, 'category', 'Pale Bitter European Beer'),
+    ('6', NULL, 'category', 'Amber Malty European Lager'),
+    ('7', NULL, 'category', 'Amber Bitter European Beer'),
+    ('8', NULL, 'category', 'Dark European Lager'),
+    ('9', NULL, 'category', 'Strong European Beer'),
+    ('10', NULL, 'category', 'German Wheat Beer'),
+    ('11', NULL, 'category', 'British Bitter'),
+    ('12', NULL, 'category', 'Pale Commonwealth Beer'),
+    ('13', NULL, 'category', 'Brown British Beer'),
+    ('14', NULL, 'category', 'Scottish Ale'),
+    ('15', NULL, 'category', 'Irish Beer'),
+    ('16', NULL, 'category', 'Dark British Beer'),
+    ('17', NULL, 'category', 'Strong British Ale'),
+    ('18', NULL, 'category', 'Pale American Ale'),
+    ('19', NULL, 'category', 'Amber and Brown American Beer'),
+    ('20', NULL, 'category', 'American Porter and Stout'),
+    ('21', NULL, 'category', 'IPA'),
+    ('22', NULL, 'category', 'Strong American Ale'),
+    ('23', NULL, 'category', 'European Sour Ale'),
+    ('24', NULL, 'category', 'Belgian Ale'),
+    ('25', NULL, 'category', 'Strong Belgian Ale'),
+    ('26', NULL, 'category', 'Monastic Ale'),
+    ('27', NULL, 'category', 'Historical Beer'),
+    ('28', NULL, 'category', 'American Wild Ale'),
+    ('29', NULL, 'category', 'Fruit Beer'),
+    ('30', NULL, 'category', 'Spiced Beer'),
+    ('31', NULL, 'category', 'Alternative Fermentables Beer'),
+    ('32', NULL, 'category', 'Smoked Beer'),
+    ('33', NULL, 'category', 'Wood Beer'),
+    ('34', NULL, 'category', 'Specialty Beer');
+
+INSERT INTO "styles" ("id", "parent_id", "level", "name", "abv_min", "abv_max", "ibu_min", "ibu_max", "srm_min", "srm_max", "og_min", "og_max", "fg_min", "fg_max") VALUES
+    ('1A', '1', 'style', 'American Light Lager', 2.8, 4.2, 8, 12, 2, 3, 1.028, 1.040, 0.998, 1.008),
+    ('1B', '1', 'style', 'American Lager', 4.2, 5.3, 8, 18, 2, 3.5, 1.040, 1.050, 1.004, 1.010),
+    ('1C', '1', 'style', 'Cream Ale', 4.2, 5.6, 8, 20, 2, 5, 1.042, 1.055, 1.006, 1.012),
+    ('1D', '1', 'style', 'American Wheat Beer', 4.0, 5.5, 15, 30, 3, 6, 1.040, 1.055, 1.008, 1.013),
+    ('2A', '2', 'style', 'International Pale Lager', 4.6, 6.0, 18, 25, 2, 6, 1.042, 1.050, 1.008, 1.012),
+    ('2B', '2', 'style', 'International Amber Lager', 4.6, 6.0, 8, 25, 7, 14, 1.042, 1.055, 1.008, 1.014),
+    ('2C', '2', 'style', 'International Dark Lager', 4.2, 6.0, 8, 20, 14, 30, 1.044, 1.056, 1.008, 1.012),
+    ('3A', '3', 'style', 'Czech Pale Lager', 3.0, 4.1, 20, 35, 3, 6, 1.028, 1.044, 1.008, 1.014),
+    ('3B', '3', 'style', 'Czech Premium Pale Lager', 4.2, 5.8, 30, 45, 3.5, 6, 1.044, 1.060, 1.013, 1.017),
+    ('3C', '3', 'style', 'Czech Amber Lager', 4.4, 5.8, 20, 35, 10, 16, 1.044, 1.060, 1.013, 1.017),
+    ('3D', '3', 'style', 'Czech Dark Lager', 4.4, 5.8, 18, 34, 17, 35, 1.044, 1.060, 1.013, 1.017),
+    ('4A', '4', 'style', 'Munich Helles', 4.7, 5.4, 16, 22, 3, 5, 1.044, 1.048, 1.006, 1.012),
+    ('4B', '4', 'style', 'Festbier', 5.8, 6.3, 18, 25, 4, 6, 1.054, 1.057, 1.010, 1.012),
+    ('4C', '4', 'style', 'Helles Bock', 6.3, 7.4, 23, 35, 6, 9, 1.064, 1.072, 1.011, 1.018),
+    ('5A', '5', 'style', 'German Leichtbier', 2.4, 3.6, 15, 28, 1.5, 4, 1.026, 1.034, 1.006, 1.010),
+    ('5B', '5', 'style', 'Kölsch', 4.4, 5.2, 18, 30, 3.5, 5, 1.044, 1.050, 1.007, 1.011),
+    ('5C', '5', 'style', 'German Helles Exportbier', 4.8, 6.0, 20, 30, 4, 6, 1.048, 1.056, 1.010, 1.015),
+    ('5D', '5', 'style', 'German Pils', 4.4, 5.2, 22, 40, 2, 4, 1.044, 1.050, 1.008, 1.013),
+    ('6A', '6', 'style', 'Märzen', 5.6, 6.3, 18, 24, 8, 17, 1.054, 1.060, 1.010, 1.014),
+    ('6B', '6', 'style', 'Rauchbier', 4.8, 6.0, 20, 30, 12, 22, 1.050, 1.057, 1.012, 1.016),
+    ('6C', '6', 'style', 'Dunkles Bock', 6.3, 7.2, 20, 27, 14, 22, 1.064, 1.072, 1.013, 1.019),
+    ('7A', '7', 'style', 'Vienna Lager', 4.7, 5.5, 18, 30, 9, 15, 1.048, 1.055, 1.010, 1.014),
+    ('7B', '7', 'style', 'Altbier', 4.3, 5.5, 25, 50, 9, 17, 1.044, 1.052, 1.008, 1.014),
+    ('8A', '8', 'style', 'Munich Dunkel', 4.5, 5.6, 18, 28, 17, 28, 1.048, 1.056, 1.010, 1.016),
+    ('8B', '8', 'style', 'Schwarzbier', 4.4, 5.4, 20, 35, 19, 30, 1.046, 1.052, 1.010, 1.016),
+    ('9A', '9', 'style', 'Doppelbock', 7.0, 10.0, 16, 26, 6, 25, 1.072, 1.112, 1.016, 1.024),
+    ('9B', '9', 'style', 'Eisbock', 9.0, 14.0, 25, 35, 18, 30, 1.078, 1.120, 1.020, 1.035),
+    ('9C', '9', 'style', 'Baltic Porter', 6.5, 9.5, 20, 40, 17, 30, 1.060, 1.090, 1.016, 1.024),
+    ('10A', '10', 'style', 'Weissbier', 4.3, 5.6, 8, 15, 2, 6, 1.044, 1.053, 1.008, 1.014),
+    ('10B', '10', 'style', 'Dunkles Weissbier', 4.3, 5.6, 10, 18, 14, 23, 1.044, 1.057, 1.008, 1.014),
+    ('10C', '10', 'style', 'Weizenbock', 6.5, 9.0, 15, 30, 6, 25, 1.064, 1.090, 1.015, 1.022),
+    ('11A', '11', 'style', 'Ordinary Bitter', 3.2, 3.8, 25, 35, 8, 14, 1.030, 1.039, 1.007, 1.011),
+    ('11B', '11', 'style', 'Best Bitter', 3.8, 4.6, 25, 40, 8, 16, 1.040, 1.048, 1.008, 1.012),
+    ('11C', '11', 'style', 'Strong Bitter', 4.6, 6.2, 30, 50, 8, 18, 1.048, 1.060, 1.010, 1.016),
+    ('12A', '12', 'style', 'British Golden Ale', 3.8, 5.0, 20, 45, 2, 6, 1.038, 1.053, 1.006, 1.012),
+    ('12B', '12', 'style', 'Australian Sparkling Ale', 4.5, 6.0, 20, 35, 4, 7, 1.038, 1.050, 1.004, 1.006),
+    ('12C', '12', 'style', 'English IPA', 5.0, 7.5, 40, 60, 6, 14, 1.050, 1.070, 1.010, 1.015),
+    ('13A', '13', 'style', 'Dark Mild', 3.0, 3.8, 10, 25, 14, 25, 1.030, 1.038, 1.008, 1.013),
+    ('13B', '13', 'style', 'British Brown Ale', 4.2, 5.9, 20, 30, 12, 22, 1.040, 1.052, 1.008, 1.013),
+    ('13C', '13', 'style', 'English Porter', 4.0, 5.4, 18, 35, 20, 30, 1.040, 1.052, 1.008, 1.014),
+    ('14A', '14', 'style', 'Scottish Light', 2.5, 3.2, 10, 20, 17, 25, 1.030, 1.035, 1.010, 1.013),
+    ('14B', '14', 'style', 'Scottish Heavy', 3.2, 3.9, 10, 20, 12, 20, 1.035, 1.040, 1.010, 1.015),
+    ('14C', '14', 'style', 'Scottish Export', 3.9, 6.0, 15, 30, 12, 20, 1.040, 1.060, 1.010, 1.016),
+    ('15A', '15', 'style', 'Irish Red Ale', 3.8, 5.0, 18, 28, 9, 14, 1.036, 1.046, 1.010, 1.014),
+    ('15B', '15', 'style', 'Irish Stout', 3.8, 5.0, 25, 45, 25, 40, 1.036, 1.044, 1.007, 1.011),
+    ('15C', '15', 'style', 'Irish Extra Stout', 5.0, 6.5, 35, 50, 30, 40, 1.052, 1.062, 1.010, 1.014),
+    ('16A', '16', 'style', 'Sweet Stout', 4.0, 6.0, 20, 40, 30, 40, 1.044, 1.060, 1.012, 1.024),
+    ('16B', '16', 'style', 'Oatmeal Stout', 4.2, 5.9, 25, 40, 22, 40, 1.045, 1.065, 1.010, 1.018),
+    ('16C', '16', 'style', 'Tropical Stout', 5.5, 8.0, 30, 50, 30, 40, 1.056, 1.075, 1.010, 1.018),
+    ('16D', '16', 'style', 'Foreign Extra Stout', 6.3, 8.0, 50, 70, 30, 40, 1.056, 1.075, 1.010, 1.018),
+    ('17A', '17', 'style', 'British Strong Ale', 5.5, 8.0, 30, 60, 8, 22, 1.055, 1.080, 1.015, 1.022),
+    ('17B', '17', 'style', 'Old Ale', 5.5, 9.0, 30, 60, 10, 22, 1.055, 1.088, 1.015, 1.022),
+    ('17C', '17', 'style', 'Wee Heavy', 6.5, 10.0, 17, 35, 14, 25, 1.070, 1.130, 1.018, 1.040),
+    ('17D', '17', 'style', 'English Barley Wine', 8.0, 12.0, 35, 70, 8, 22, 1.080, 1.120, 1.018, 1.030),
+    ('18A', '18', 'style', 'Blonde Ale', 3.8, 5.5, 15, 28, 3, 6, 1.038, 1.054, 1.008, 1.013),
+    ('18B', '18', 'style', 'American Pale Ale', 4.5, 6.2, 30, 50, 5, 10, 1.045, 1.060, 1.010, 1.015),
+    ('19A', '19', 'style', 'American Amber Ale', 4.5, 6.2, 25, 40, 10, 17, 1.045, 1.060, 1.010, 1.015),
+    ('19B', '19', 'style', 'California Common', 4.5, 5.5, 30, 45, 9, 14, 1.048, 1.054, 1.011, 1.014),
+    ('19C', '19', 'style', 'American Brown Ale', 4.3, 6.2, 20, 30, 18, 35, 1.045, 1.060, 1.010, 1.016),
+    ('20A', '20', 'style', 'American Porter', 4.8, 6.5, 25, 50, 22, 40, 1.050, 1.070, 1.012, 1.018),
+    ('20B', '20', 'style', 'American Stout', 5.0, 7.0, 35, 75, 30, 40, 1.050, 1.075, 1.010, 1.022),
+    ('20C', '20', 'style', 'Imperial Stout', 8.0, 12.0, 50, 90, 30, 40, 1.075, 1.115, 1.018, 1.030),
+    ('21A', '21', 'style', 'American IPA', 5.5, 7.5, 40, 70, 6, 14, 1.056, 1.070, 1.008, 1.014),
+    ('21C', '21', 'style', 'Hazy IPA', 6.0, 9.0, 25, 60, 3, 7, 1.060, 1.085, 1.010, 1.015),
+    ('22A', '22', 'style', 'Double IPA', 7.5, 10.0, 60, 100, 6, 14, 1.065, 1.085, 1.008, 1.018),
+    ('22B', '22', 'style', 'American Strong Ale', 6.3, 10.0, 50, 100, 7, 18, 1.062, 1.090, 1.014, 1.024),
+    ('22C', '22', 'style', 'American Barleywine', 8.0, 12.0, 50, 100, 9, 18, 1.080, 1.120, 1.016, 1.030),
+    ('22D', '22', 'style', 'Wheatwine', 8.0, 12.0, 30, 60, 6, 14, 1.080, 1.120, 1.016, 1.030),
+    ('23A', '23', 'style', 'Berliner Weisse', 2.8, 3.8, 3, 8, 2, 3, 1.028, 1.032, 1.003, 1.006),
+    ('23B', '23', 'style', 'Flanders Red Ale', 4.6, 6.5, 10, 25, 10, 16, 1.048, 1.057, 1.002, 1.012),
+    ('23C', '23', 'style', 'Oud Bruin', 4.0, 8.0, 20, 25, 17, 35, 1.040, 1.074, 1.008, 1.012),
+    ('23D', '23', 'style', 'Lambic', 5.0, 6.5, 0, 10, 3, 6, 1.040, 1.054, 1.001, 1.010),
+    ('23E', '23', 'style', 'Gueuze', 5.0, 8.0, 0, 10, 5, 6, 1.040, 1.060, 1.000, 1.006),
+    ('23F', '23', 'style', 'Fruit Lambic', 5.0, 7.0, 0, 10, 3, 7, 1.040, 1.060, 1.000, 1.010),
+    ('23G', '23', 'style', 'Gose', 4.2, 4.8, 5, 12, 3, 4, 1.036, 1.056, 1.006, 1.010),
+    ('24A', '24', 'style', 'Witbier', 4.5, 5.5, 8, 20, 2, 4, 1.044, 1.052, 1.008, 1.012),
+    ('24B', '24', 'style', 'Belgian Pale Ale', 4.8, 5.5, 20, 30, 8, 14, 1.048, 1.054, 1.010, 1.014),
+    ('24C', '24', 'style', 'Bière de Garde', 6.0, 8.5, 18, 28, 6, 19, 1.060, 1.080, 1.008, 1.016),
+    ('25A', '25', 'style', 'Belgian Blond Ale', 6.0, 7.5, 15, 30, 4, 6, 1.062, 1.075, 1.008, 1.018),
+    ('25B', '25', 'style', 'Saison', 3.5, 9.5, 20, 35, 5, 22, 1.048, 1.065, 1.002, 1.008),
+    ('25C', '25', 'style', 'Belgian Golden Strong Ale', 7.5, 10.5, 22, 35, 3, 6, 1.070, 1.095, 1.005, 1.016),
+    ('26A', '26', 'style', 'Belgian Single', 4.8, 6.0, 25, 45, 3, 5, 1.044, 1.054, 1.004, 1.010),
+    ('26B', '26', 'style', 'Belgian Dubbel', 6.0, 7.6, 15, 25, 10, 17, 1.062, 1.075, 1.008, 1.018),
+    ('26C', '26', 'style', 'Belgian Tripel', 7.5, 9.5, 20, 40, 4.5, 7, 1.075, 1.085, 1.008, 1.014),
+    ('26D', '26', 'style', 'Belgian Dark Strong Ale', 8.0, 12.0, 20, 35, 12, 22, 1.075, 1.110, 1.010, 1.024),
+    ('28D', '28', 'style', 'Straight Sour Beer', 4.5, 7.0, 3, 8, 2, 3, 1.048, 1.065, 1.006, 1.013);
+
+-- The specialty styles have no vital statistics of their own, they depend
+-- on the base style, fruit, spice or wood they are brewed with.
+INSERT INTO "styles" ("id", "parent_id", "level", "name") VALUES
+    ('21B', '21', 'style', 'Specialty IPA'),
+    ('27A', '27', 'style', 'Historical Beer'),
+    ('28A', '28', 'style', 'Brett Beer'),
+    ('28B', '28', 'style', 'Mixed-Fermentation Sour Beer'),
+    ('28C', '28', 'style', 'Wild Specialty Beer'),
+    ('29A', '29', 'style', 'Fruit Beer'),
+    ('29B', '29', 'style', 'Fruit and Spice Beer'),
+    ('29C', '29', 'style', 'Specialty Fruit Beer'),
+    ('29D', '29', 'style', 'Grape Ale'),
+    ('30A', '30', 'style', 'Spice, Herb, or Vegetable Beer'),
+    ('30B', '30', 'style', 'Autumn Seasonal Beer'),
+    ('30C', '30', 'style', 'Winter Seasonal Beer'),
+    ('30D', '30', 'style', 'Specialty Spice Beer'),
+    ('31A', '31', 'style', 'Alternative Grain Beer'),
+    ('31B', '31', 'style', 'Alternative Sugar Beer'),
+    ('32A', '32', 'style', 'Classic Style Smoked Beer'),
+    ('32B', '32', 'style', 'Specialty Smoked Beer'),
+    ('33A', '33', 'style', 'Wood-Aged Beer'),
+    ('33B', '33', 'style', 'Specialty Wood-Aged Beer'),
+    ('34A', '34', 'style', 'Commercial Specialty Beer'),
+    ('34B', '34', 'style', 'Mixed-Style Beer'),
+    ('34C', '34', 'style', 'Experimental Beer'),
+    ('27A-KELLERBIER', '27A', 'substyle', 'Kellerbier');
+
+INSERT INTO "styles" ("id", "parent_id", "level", "name", "abv_min", "abv_max", "ibu_min", "ibu_max", "srm_min", "srm_max", "og_min", "og_max", "fg_min", "fg_max") VALUES
+    ('21B-BELGIAN', '21B', 'substyle', 'Belgian IPA', 6.2, 9.5, 50, 100, 5, 15, 1.058, 1.080, 1.008, 1.016),
+    ('21B-BLACK', '21B', 'substyle', 'Black IPA', 5.5, 9.0, 50, 90, 25, 40, 1.050, 1.085, 1.010, 1.018),
+    ('21B-BROWN', '21B', 'substyle', 'Brown IPA', 5.5, 7.5, 40, 70, 11, 19, 1.056, 1.070, 1.008, 1.016),
+    ('21B-RED', '21B', 'substyle', 'Red IPA', 5.5, 7.5, 40, 70, 11, 19, 1.056, 1.070, 1.008, 1.016),
+    ('21B-RYE', '21B', 'substyle', 'Rye IPA', 5.5, 8.0, 50, 75, 6, 14, 1.056, 1.075, 1.008, 1.014),
+    ('21B-WHITE', '21B', 'substyle', 'White IPA', 5.5, 7.0, 40, 70, 5, 8, 1.056, 1.065, 1.010, 1.016),
+    ('21B-BRUT', '21B', 'substyle', 'Brut IPA', 6.0, 7.5, 20, 30, 2, 4, 1.046, 1.057, 0.990, 1.004),
+    ('27A-KENTUCKY', '27A', 'substyle', 'Kentucky Common', 4.0, 5.5, 15, 30, 11, 20, 1.044, 1.055, 1.010, 1.018),
+    ('27A-LICHTENHAINER', '27A', 'substyle', 'Lichtenhainer', 3.5, 4.7, 5, 12, 3, 6, 1.032, 1.040, 1.004, 1.008),
+    ('27A-LONDON', '27A', 'substyle', 'London Brown Ale', 2.8, 3.6, 15, 20, 22, 35, 1.033, 1.038, 1.012, 1.015),
+    ('27A-GRODZISKIE', '27A', 'substyle', 'Piwo Grodziskie', 2.5, 3.3, 20, 35, 3, 6, 1.028, 1.032, 1.006, 1.012),
+    ('27A-PRELAGER', '27A', 'substyle', 'Pre-Prohibition Lager', 4.5, 6.0, 25, 40, 3, 6, 1.044, 1.060, 1.010, 1.015),
+    ('27A-PREPORTER', '27A', 'substyle', 'Pre-Prohibition Porter', 4.5, 6.0, 20, 30, 18, 30, 1.046, 1.060, 1.010, 1.016),
+    ('27A-ROGGENBIER', '27A', 'substyle', 'Roggenbier', 4.5, 6.0, 10, 20, 14, 19, 1.046, 1.056, 1.010, 1.014),
+    ('27A-SAHTI', '27A', 'substyle', 'Sahti', 7.0, 11.0, 0, 15, 4, 22, 1.076, 1.120, 1.016, 1.038);
+
+-- Map the styles of existing beers onto the taxonomy: names matching but for
+-- case or accents, and the styles renamed by the 2021 guidelines. The accents
+-- are folded with TRANSLATE so the migration needs no extension.
+UPDATE "beers" AS b
+SET "style" = s."name"
+FROM "styles" AS s
+WHERE s."level" <> 'category'
+    AND b."style" <> s."name"
+    AND TRANSLATE(LOWER(b."style"), 'äàáâãåçèéêëìíîïñòóôõöùúûüýÿ', 'aaaaaaceeeeiiiinooooouuuuyy')
+        = TRANSLATE(LOWER(s."name"), 'äàáâãåçèéêëìíîïñòóôõöùúûüýÿ', 'aaaaaaceeeeiiiinooooouuuuyy');
+
+UPDATE "beers" AS b
+SET "style" = r."name"
+FROM (VALUES
+    ('trappist single', 'Belgian Single'),
+    ('new england ipa', 'Hazy IPA'),
+    ('neipa', 'Hazy IPA'),
+    ('oktoberfest', 'Märzen'),
+    ('imperial ipa', 'Double IPA'),
+    ('russian imperial stout', 'Imperial Stout'),
+    ('hefeweizen', 'Weissbier'),
+    ('dunkelweizen', 'Dunkles Weissbier')
+) AS r ("alias", "name")
+WHERE LOWER(b."style") = r."alias";
+
+-- The beers left with a style outside of the taxonomy can't keep it when
+-- their style is updated, so they are listed for an admin to fix.
+CREATE OR REPLACE VIEW "beers_unknown_style" AS
+SELECT b."id", b."name", b."style"
+FROM "beers" AS b
+WHERE NOT EXISTS (
+    SELECT 1 FROM "styles" AS s
+    WHERE s."level" <> 'category' AND LOWER(s."name") = LOWER(b."style")
+);
+
+DO $$
+DECLARE
+    unknown INT;
+BEGIN
+    SELECT COUNT(*) INTO unknown FROM "beers_unknown_style";
+    IF unknown > 0 THEN
+        RAISE NOTICE '% beers have a style outside of the BJCP 2021 taxonomy, see the beers_unknown_style view', unknown;
+    END IF;
+END $$;
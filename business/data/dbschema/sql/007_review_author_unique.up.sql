@@ -0,0 +1,20 @@
+-- Keep only the most recent review of each user for a beer.
+DELETE FROM "reviews" r
+USING "reviews" newer
+WHERE r."beer_id" = newer."beer_id"
+  AND r."user_id" = newer."user_id"
+  AND (r."created_at", r."id") < (newer."created_at", newer."id");
+
+-- Recompute the aggregates for the remaining reviews.
+UPDATE "beer_stats" bs SET
+    "review_count" = agg."review_count",
+    "avg_score" = agg."avg_score",
+    "last_reviewed_at" = agg."last_reviewed_at"
+FROM (
+    SELECT "beer_id", COUNT(*) AS "review_count", AVG("score") AS "avg_score", MAX("created_at") AS "last_reviewed_at"
+    FROM "reviews"
+    GROUP BY "beer_id"
+) agg
+WHERE bs."beer_id" = agg."beer_id";
+
+ALTER TABLE "reviews" ADD CONSTRAINT "reviews_beer_id_user_id_key" UNIQUE ("beer_id", "user_id");
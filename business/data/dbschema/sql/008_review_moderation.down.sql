@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS "review_reports";
+DROP INDEX IF EXISTS "reviews_moderation_idx";
+ALTER TABLE "reviews"
+    DROP COLUMN IF EXISTS "moderated_at",
+    DROP COLUMN IF EXISTS "moderated_by",
+    DROP COLUMN IF EXISTS "moderation_reason",
+    DROP COLUMN IF EXISTS "report_count",
+    DROP COLUMN IF EXISTS "status";
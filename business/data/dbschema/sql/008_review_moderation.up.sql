@@ -0,0 +1,19 @@
+ALTER TABLE "reviews"
+    ADD COLUMN "status" VARCHAR(16) NOT NULL DEFAULT 'published'
+        CHECK ("status" IN ('pending', 'published', 'rejected', 'hidden')),
+    ADD COLUMN "report_count" INT NOT NULL DEFAULT 0,
+    ADD COLUMN "moderation_reason" TEXT NULL,
+    ADD COLUMN "moderated_by" UUID NULL,
+    ADD COLUMN "moderated_at" TIMESTAMP NULL;
+
+CREATE INDEX IF NOT EXISTS "reviews_moderation_idx" ON "reviews" ("status", "report_count" DESC, "created_at")
+    WHERE "status" IN ('pending', 'hidden');
+
+CREATE TABLE IF NOT EXISTS "review_reports" (
+    "id" UUID PRIMARY KEY,
+    "created_at" TIMESTAMP NOT NULL,
+    "review_id" UUID NOT NULL REFERENCES "reviews" ("id") ON DELETE CASCADE,
+    "user_id" UUID NOT NULL,
+    "reason" TEXT NOT NULL,
+    UNIQUE ("review_id", "user_id")
+);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS "reviews_beer_id_helpfulness_idx";
+ALTER TABLE "reviews"
+    DROP COLUMN IF EXISTS "helpfulness",
+    DROP COLUMN IF EXISTS "unhelpful_count",
+    DROP COLUMN IF EXISTS "helpful_count";
+DROP TABLE IF EXISTS "review_votes";
+DROP FUNCTION IF EXISTS "wilson_lower_bound";
@@ -0,0 +1,31 @@
+-- Lower bound of the Wilson score confidence interval, at 95% confidence, for
+-- the share of helpful votes. Reviews with few votes rank below reviews with
+-- the same share backed by many votes.
+CREATE OR REPLACE FUNCTION "wilson_lower_bound"(helpful INT, unhelpful INT) RETURNS REAL AS $$
+DECLARE
+    n DOUBLE PRECISION := helpful + unhelpful;
+    z DOUBLE PRECISION := 1.96;
+    p DOUBLE PRECISION;
+BEGIN
+    IF n = 0 THEN
+        RETURN 0;
+    END IF;
+    p := helpful / n;
+    RETURN (p + z * z / (2 * n) - z * SQRT((p * (1 - p) + z * z / (4 * n)) / n)) / (1 + z * z / n);
+END;
+$$ LANGUAGE plpgsql IMMUTABLE;
+
+CREATE TABLE IF NOT EXISTS "review_votes" (
+    "review_id" UUID NOT NULL REFERENCES "reviews" ("id") ON DELETE CASCADE,
+    "user_id" UUID NOT NULL,
+    "helpful" BOOLEAN NOT NULL,
+    "created_at" TIMESTAMP NOT NULL,
+    PRIMARY KEY ("review_id", "user_id")
+);
+
+ALTER TABLE "reviews"
+    ADD COLUMN "helpful_count" INT NOT NULL DEFAULT 0,
+    ADD COLUMN "unhelpful_count" INT NOT NULL DEFAULT 0,
+    ADD COLUMN "helpfulness" REAL NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS "reviews_beer_id_helpfulness_idx" ON "reviews" ("beer_id", "helpfulness" DESC, "id");
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS "wishlist_items" (
+    "user_id" UUID NOT NULL,
+    "beer_id" UUID NOT NULL REFERENCES "beers" ("id") ON DELETE CASCADE,
+    "created_at" TIMESTAMP NOT NULL,
+    PRIMARY KEY ("user_id", "beer_id")
+);
+
+CREATE INDEX IF NOT EXISTS "wishlist_items_user_id_created_at_idx" ON "wishlist_items" ("user_id", "created_at" DESC);
@@ -0,0 +1,2 @@
+ALTER TABLE "beer_stats" DROP COLUMN IF EXISTS "checkin_count";
+DROP TABLE IF EXISTS "checkins";
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS "checkins" (
+    "id" UUID PRIMARY KEY,
+    "user_id" UUID NOT NULL,
+    "beer_id" UUID NOT NULL REFERENCES "beers" ("id") ON DELETE CASCADE,
+    "venue" VARCHAR(255) NULL,
+    "serving" VARCHAR(16) NOT NULL CHECK ("serving" IN ('draft', 'bottle', 'can', 'cask')),
+    "rating" FLOAT NULL,
+    "checked_in_at" TIMESTAMP NOT NULL,
+    "created_at" TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS "checkins_user_id_checked_in_at_idx" ON "checkins" ("user_id", "checked_in_at" DESC, "id" DESC);
+CREATE INDEX IF NOT EXISTS "checkins_beer_id_idx" ON "checkins" ("beer_id");
+
+ALTER TABLE "beer_stats" ADD COLUMN "checkin_count" INT NOT NULL DEFAULT 0;
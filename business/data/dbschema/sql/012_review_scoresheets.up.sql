@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS "review_scoresheets" (
+    "review_id" UUID PRIMARY KEY REFERENCES "reviews" ("id") ON DELETE CASCADE,
+    "aroma" REAL NOT NULL CHECK ("aroma" BETWEEN 0 AND 12),
+    "appearance" REAL NOT NULL CHECK ("appearance" BETWEEN 0 AND 3),
+    "flavor" REAL NOT NULL CHECK ("flavor" BETWEEN 0 AND 20),
+    "mouthfeel" REAL NOT NULL CHECK ("mouthfeel" BETWEEN 0 AND 5),
+    "overall" REAL NOT NULL CHECK ("overall" BETWEEN 0 AND 10)
+);
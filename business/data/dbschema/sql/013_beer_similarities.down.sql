@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS "reviews_user_id_idx";
+DROP TABLE IF EXISTS "beer_similarities";
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS "beer_similarities" (
+    "beer_id" UUID NOT NULL REFERENCES "beers" ("id") ON DELETE CASCADE,
+    "similar_beer_id" UUID NOT NULL REFERENCES "beers" ("id") ON DELETE CASCADE,
+    "score" REAL NOT NULL,
+    "common_users" INT NOT NULL,
+    "computed_at" TIMESTAMP NOT NULL,
+    PRIMARY KEY ("beer_id", "similar_beer_id")
+);
+
+CREATE INDEX IF NOT EXISTS "beer_similarities_beer_id_score_idx" ON "beer_similarities" ("beer_id", "score" DESC);
+CREATE INDEX IF NOT EXISTS "reviews_user_id_idx" ON "reviews" ("user_id");
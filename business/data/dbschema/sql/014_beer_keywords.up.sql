@@ -0,0 +1,5 @@
+-- The distinct stemmed words of the description, used to compare beers.
+ALTER TABLE "beers" ADD COLUMN IF NOT EXISTS "keywords" TEXT[]
+    GENERATED ALWAYS AS (
+        tsvector_to_array(to_tsvector('english', coalesce("short_desc", '')))
+    ) STORED;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS "reviews_beer_id_created_at_idx";
+DROP INDEX IF EXISTS "reviews_published_created_at_idx";
@@ -0,0 +1,4 @@
+-- Support the trending beers: the recent reviews are found by date, and the
+-- baseline of every trending beer by beer and date.
+CREATE INDEX IF NOT EXISTS "reviews_published_created_at_idx" ON "reviews" ("created_at", "beer_id") WHERE "status" = 'published';
+CREATE INDEX IF NOT EXISTS "reviews_beer_id_created_at_idx" ON "reviews" ("beer_id", "created_at");
@@ -0,0 +1,9 @@
+-- Bayesian ratings of the reviewed beers, precomputed on a schedule since
+-- every rating depends on the mean score of all the reviews.
+CREATE TABLE IF NOT EXISTS "beer_ratings" (
+    "beer_id" UUID PRIMARY KEY REFERENCES "beers" ("id") ON DELETE CASCADE,
+    "rating" REAL NOT NULL,
+    "computed_at" TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS "beer_ratings_rating_idx" ON "beer_ratings" ("rating" DESC);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "beer_images";
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS "beer_images" (
+    "id" UUID PRIMARY KEY,
+    "beer_id" UUID NOT NULL REFERENCES "beers" ("id") ON DELETE CASCADE,
+    "key" VARCHAR(255) NOT NULL,
+    "url" VARCHAR(1024) NOT NULL,
+    "content_type" VARCHAR(64) NOT NULL,
+    "size" INT NOT NULL,
+    "created_at" TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS "beer_images_beer_id_idx" ON "beer_images" ("beer_id", "created_at");
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "beer_tags";
+DROP TABLE IF EXISTS "tags";
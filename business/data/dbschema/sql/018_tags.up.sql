@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS "tags" (
+    "id" UUID PRIMARY KEY,
+    "name" VARCHAR(50) NOT NULL,
+    "curated" BOOLEAN NOT NULL DEFAULT FALSE,
+    "created_at" TIMESTAMP NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "tags_name_idx" ON "tags" ("name");
+
+CREATE TABLE IF NOT EXISTS "beer_tags" (
+    "beer_id" UUID NOT NULL REFERENCES "beers" ("id") ON DELETE CASCADE,
+    "tag_id" UUID NOT NULL REFERENCES "tags" ("id") ON DELETE CASCADE,
+    PRIMARY KEY ("beer_id", "tag_id")
+);
+
+CREATE INDEX IF NOT EXISTS "beer_tags_tag_id_idx" ON "beer_tags" ("tag_id");
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "beers_brewery_id_normalized_name_idx";
+ALTER TABLE "beers" DROP COLUMN IF EXISTS "forced";
+ALTER TABLE "beers" DROP COLUMN IF EXISTS "normalized_name";
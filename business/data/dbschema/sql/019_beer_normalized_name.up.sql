@@ -0,0 +1,35 @@
+CREATE EXTENSION IF NOT EXISTS "unaccent";
+
+ALTER TABLE "beers" ADD COLUMN IF NOT EXISTS "normalized_name" TEXT;
+ALTER TABLE "beers" ADD COLUMN IF NOT EXISTS "forced" BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Backfill with the SQL equivalent of beer.NormalizeName, unaccent folding
+-- the accents and letters like ß or ø the same way. Beers already duplicated
+-- are marked as forced, but the oldest, so the unique index can be built.
+WITH "normalized" AS (
+    SELECT "id", "brewery_id", "created_at", TRIM(REGEXP_REPLACE(
+        REGEXP_REPLACE(
+            REGEXP_REPLACE(
+                REGEXP_REPLACE(
+                    REGEXP_REPLACE(' ' || LOWER(REPLACE(unaccent("name"), '&', ' and ')) || ' ', '[^[:alnum:]]+', ' ', 'g'),
+                    ' india pale ale ', ' ipa ', 'g'),
+                ' india pale lager ', ' ipl ', 'g'),
+            ' extra special bitter ', ' esb ', 'g'),
+        '\s+', ' ', 'g')) AS "normalized_name"
+    FROM "beers"
+), "ranked" AS (
+    SELECT "id", "normalized_name",
+        ROW_NUMBER() OVER (PARTITION BY "brewery_id", "normalized_name" ORDER BY "created_at", "id") AS "rank"
+    FROM "normalized"
+)
+UPDATE "beers" AS b
+SET "normalized_name" = r."normalized_name", "forced" = r."rank" > 1
+FROM "ranked" AS r
+WHERE r."id" = b."id";
+
+ALTER TABLE "beers" ALTER COLUMN "normalized_name" SET NOT NULL;
+
+-- Beers forced as duplicates are left out of the uniqueness check.
+CREATE UNIQUE INDEX IF NOT EXISTS "beers_brewery_id_normalized_name_idx"
+    ON "beers" ("brewery_id", "normalized_name")
+    WHERE NOT "forced";
@@ -0,0 +1,8 @@
+-- Beers merged into another beer redirect to it.
+CREATE TABLE IF NOT EXISTS "beer_redirects" (
+    "old_id" UUID PRIMARY KEY,
+    "beer_id" UUID NOT NULL REFERENCES "beers" ("id") ON DELETE CASCADE,
+    "created_at" TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS "beer_redirects_beer_id_idx" ON "beer_redirects" ("beer_id");
@@ -0,0 +1,17 @@
+-- Soft deleted rows would violate the unique constraints restored below.
+DELETE FROM "reviews" WHERE "deleted_at" IS NOT NULL;
+DELETE FROM "beers" WHERE "deleted_at" IS NOT NULL;
+
+DROP INDEX IF EXISTS "beers_brewery_id_normalized_name_idx";
+CREATE UNIQUE INDEX IF NOT EXISTS "beers_brewery_id_normalized_name_idx"
+    ON "beers" ("brewery_id", "normalized_name")
+    WHERE NOT "forced";
+
+DROP INDEX IF EXISTS "reviews_beer_id_user_id_key";
+ALTER TABLE "reviews" ADD CONSTRAINT "reviews_beer_id_user_id_key" UNIQUE ("beer_id", "user_id");
+
+DROP INDEX IF EXISTS "reviews_deleted_at_idx";
+DROP INDEX IF EXISTS "beers_deleted_at_idx";
+
+ALTER TABLE "reviews" DROP COLUMN IF EXISTS "deleted_at";
+ALTER TABLE "beers" DROP COLUMN IF EXISTS "deleted_at";
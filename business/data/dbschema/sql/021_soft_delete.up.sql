@@ -0,0 +1,18 @@
+-- Deleted beers and reviews are kept until the purge job hard deletes them
+-- once the retention period is over.
+ALTER TABLE "beers" ADD COLUMN IF NOT EXISTS "deleted_at" TIMESTAMP NULL;
+ALTER TABLE "reviews" ADD COLUMN IF NOT EXISTS "deleted_at" TIMESTAMP NULL;
+
+CREATE INDEX IF NOT EXISTS "beers_deleted_at_idx" ON "beers" ("deleted_at") WHERE "deleted_at" IS NOT NULL;
+CREATE INDEX IF NOT EXISTS "reviews_deleted_at_idx" ON "reviews" ("deleted_at") WHERE "deleted_at" IS NOT NULL;
+
+-- Deleted rows no longer count for uniqueness, so a deleted review doesn't
+-- stop its author from reviewing the beer again, nor a deleted beer from
+-- adding it again.
+ALTER TABLE "reviews" DROP CONSTRAINT IF EXISTS "reviews_beer_id_user_id_key";
+CREATE UNIQUE INDEX IF NOT EXISTS "reviews_beer_id_user_id_key" ON "reviews" ("beer_id", "user_id") WHERE "deleted_at" IS NULL;
+
+DROP INDEX IF EXISTS "beers_brewery_id_normalized_name_idx";
+CREATE UNIQUE INDEX IF NOT EXISTS "beers_brewery_id_normalized_name_idx"
+    ON "beers" ("brewery_id", "normalized_name")
+    WHERE NOT "forced" AND "deleted_at" IS NULL;
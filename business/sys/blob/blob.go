@@ -0,0 +1,50 @@
+// Package blob provides support for storing binary objects, like images,
+// behind an interface so the storage backend can be swapped.
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path"
+	"strings"
+)
+
+// Set of error variables for blob storage.
+var (
+	ErrNotFound   = errors.New("blob not found")
+	ErrInvalidKey = errors.New("blob key is not in its proper form")
+)
+
+// Object is a stored blob. The caller must close the body.
+type Object struct {
+	Key         string
+	ContentType string
+	Size        int64
+	Body        io.ReadCloser
+}
+
+// Store declares the behavior of a blob storage backend. Keys are slash
+// separated paths, like beers/<id>/<image>.jpg.
+type Store interface {
+	Put(ctx context.Context, key string, contentType string, data []byte) error
+	Get(ctx context.Context, key string) (Object, error)
+	Delete(ctx context.Context, key string) error
+	URL(key string) string
+}
+
+// CheckKey validates the key is a relative path without empty, current or
+// parent directory segments, so it can't escape the storage root.
+func CheckKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || path.Clean(key) != key {
+		return ErrInvalidKey
+	}
+
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "." || segment == ".." {
+			return ErrInvalidKey
+		}
+	}
+
+	return nil
+}
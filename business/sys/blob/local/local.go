@@ -0,0 +1,125 @@
+// Package local provides a blob store keeping the blobs in a directory of
+// the local filesystem.
+package local
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/phbpx/gobeers/business/sys/blob"
+)
+
+// Store manages the set of APIs for blob access on the local filesystem.
+// The content type of a blob is derived from the extension of its key.
+type Store struct {
+	dir     string
+	baseURL string
+}
+
+// NewStore constructs a blob store rooted at dir. The blobs are expected to
+// be served under baseURL.
+func NewStore(dir string, baseURL string) Store {
+	return Store{
+		dir:     dir,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
+// Put writes the blob under the key, replacing any previous blob. The data
+// is written to a temporary file first so readers never see a partial blob.
+func (s Store) Put(ctx context.Context, key string, contentType string, data []byte) error {
+	if err := blob.CheckKey(key); err != nil {
+		return err
+	}
+
+	name := s.path(key)
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return fmt.Errorf("creating blob dir [key=%s]: %w", key, err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(name), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("creating blob [key=%s]: %w", key, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("writing blob [key=%s]: %w", key, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing blob [key=%s]: %w", key, err)
+	}
+
+	if err := os.Rename(tmp.Name(), name); err != nil {
+		return fmt.Errorf("saving blob [key=%s]: %w", key, err)
+	}
+
+	return nil
+}
+
+// Get opens the blob stored under the key.
+func (s Store) Get(ctx context.Context, key string) (blob.Object, error) {
+	if err := blob.CheckKey(key); err != nil {
+		return blob.Object{}, err
+	}
+
+	f, err := os.Open(s.path(key))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return blob.Object{}, blob.ErrNotFound
+		}
+		return blob.Object{}, fmt.Errorf("opening blob [key=%s]: %w", key, err)
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return blob.Object{}, fmt.Errorf("opening blob [key=%s]: %w", key, err)
+	}
+
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	obj := blob.Object{
+		Key:         key,
+		ContentType: contentType,
+		Size:        info.Size(),
+		Body:        f,
+	}
+
+	return obj, nil
+}
+
+// Delete removes the blob stored under the key. Deleting a missing blob is
+// not an error.
+func (s Store) Delete(ctx context.Context, key string) error {
+	if err := blob.CheckKey(key); err != nil {
+		return err
+	}
+
+	if err := os.Remove(s.path(key)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("deleting blob [key=%s]: %w", key, err)
+	}
+
+	return nil
+}
+
+// URL returns the address the blob stored under the key is served at.
+func (s Store) URL(key string) string {
+	return s.baseURL + "/" + key
+}
+
+// path returns the file holding the blob stored under the key.
+func (s Store) path(key string) string {
+	return filepath.Join(s.dir, filepath.FromSlash(key))
+}
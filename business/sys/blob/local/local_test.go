@@ -0,0 +1,67 @@
+package local_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/phbpx/gobeers/business/sys/blob"
+	"github.com/phbpx/gobeers/business/sys/blob/local"
+)
+
+func TestStore(t *testing.T) {
+	store := local.NewStore(t.TempDir(), "http://localhost:3000/v1/images/")
+
+	t.Log("Given the need to work with blobs on the local filesystem.")
+	{
+		t.Logf("\tWhen handling a single blob.")
+		{
+			ctx := context.Background()
+
+			key := "beers/test/image.png"
+			data := []byte("\x89PNG\r\n\x1a\ntest image")
+
+			if err := store.Put(ctx, "../image.png", "image/png", data); !errors.Is(err, blob.ErrInvalidKey) {
+				t.Fatalf("\t [ERROR] Should NOT be able to put a blob outside the storage : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should NOT be able to put a blob outside the storage.")
+
+			if err := store.Put(ctx, key, "image/png", data); err != nil {
+				t.Fatalf("\t [ERROR] Should be able to put a blob : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should be able to put a blob.")
+
+			obj, err := store.Get(ctx, key)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to get a blob : %s", err)
+			}
+			defer obj.Body.Close()
+
+			got, err := io.ReadAll(obj.Body)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to read a blob : %s", err)
+			}
+
+			if string(got) != string(data) || obj.ContentType != "image/png" || obj.Size != int64(len(data)) {
+				t.Fatalf("\t [ERROR] Should get back the same blob : %q %s %d", got, obj.ContentType, obj.Size)
+			}
+			t.Logf("\t [SUCCESS] Should get back the same blob.")
+
+			if url := store.URL(key); url != "http://localhost:3000/v1/images/"+key {
+				t.Fatalf("\t [ERROR] Should get back the blob url : %s", url)
+			}
+			t.Logf("\t [SUCCESS] Should get back the blob url.")
+
+			if err := store.Delete(ctx, key); err != nil {
+				t.Fatalf("\t [ERROR] Should be able to delete a blob : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should be able to delete a blob.")
+
+			if _, err := store.Get(ctx, key); !errors.Is(err, blob.ErrNotFound) {
+				t.Fatalf("\t [ERROR] Should NOT be able to get a deleted blob : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should NOT be able to get a deleted blob.")
+		}
+	}
+}
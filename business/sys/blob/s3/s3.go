@@ -0,0 +1,261 @@
+// Package s3 provides a blob store backed by an S3 compatible object storage,
+// like AWS S3 or MinIO. Requests are signed with AWS Signature Version 4 and
+// use path style addressing, {endpoint}/{bucket}/{key}, which every S3
+// compatible server supports.
+package s3
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/phbpx/gobeers/business/sys/blob"
+)
+
+// Config is the required properties to use the object storage.
+type Config struct {
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+
+	// PublicURL is the address the blobs are served at, when it's not the
+	// bucket address, like a CDN in front of the bucket.
+	PublicURL string
+}
+
+// Store manages the set of APIs for blob access on an object storage.
+type Store struct {
+	cfg    Config
+	client *http.Client
+}
+
+// NewStore constructs a blob store for the bucket of the configuration.
+func NewStore(cfg Config) Store {
+	cfg.Endpoint = strings.TrimSuffix(cfg.Endpoint, "/")
+	cfg.PublicURL = strings.TrimSuffix(cfg.PublicURL, "/")
+	if cfg.PublicURL == "" {
+		cfg.PublicURL = cfg.Endpoint + "/" + cfg.Bucket
+	}
+
+	return Store{
+		cfg:    cfg,
+		client: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// CreateBucket creates the bucket of the configuration. A bucket that
+// already exists is not an error.
+func (s Store) CreateBucket(ctx context.Context) error {
+	resp, err := s.do(ctx, http.MethodPut, "/"+s.cfg.Bucket, "", nil)
+	if err != nil {
+		return fmt.Errorf("creating bucket [bucket=%s]: %w", s.cfg.Bucket, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusConflict {
+		return nil
+	}
+
+	if err := checkResponse(resp); err != nil {
+		return fmt.Errorf("creating bucket [bucket=%s]: %w", s.cfg.Bucket, err)
+	}
+
+	return nil
+}
+
+// Put uploads the blob under the key, replacing any previous blob.
+func (s Store) Put(ctx context.Context, key string, contentType string, data []byte) error {
+	if err := blob.CheckKey(key); err != nil {
+		return err
+	}
+
+	resp, err := s.do(ctx, http.MethodPut, s.objectPath(key), contentType, data)
+	if err != nil {
+		return fmt.Errorf("putting blob [key=%s]: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp); err != nil {
+		return fmt.Errorf("putting blob [key=%s]: %w", key, err)
+	}
+
+	return nil
+}
+
+// Get downloads the blob stored under the key.
+func (s Store) Get(ctx context.Context, key string) (blob.Object, error) {
+	if err := blob.CheckKey(key); err != nil {
+		return blob.Object{}, err
+	}
+
+	resp, err := s.do(ctx, http.MethodGet, s.objectPath(key), "", nil)
+	if err != nil {
+		return blob.Object{}, fmt.Errorf("getting blob [key=%s]: %w", key, err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return blob.Object{}, blob.ErrNotFound
+	}
+
+	if err := checkResponse(resp); err != nil {
+		resp.Body.Close()
+		return blob.Object{}, fmt.Errorf("getting blob [key=%s]: %w", key, err)
+	}
+
+	obj := blob.Object{
+		Key:         key,
+		ContentType: resp.Header.Get("Content-Type"),
+		Size:        resp.ContentLength,
+		Body:        resp.Body,
+	}
+
+	return obj, nil
+}
+
+// Delete removes the blob stored under the key. Deleting a missing blob is
+// not an error.
+func (s Store) Delete(ctx context.Context, key string) error {
+	if err := blob.CheckKey(key); err != nil {
+		return err
+	}
+
+	resp, err := s.do(ctx, http.MethodDelete, s.objectPath(key), "", nil)
+	if err != nil {
+		return fmt.Errorf("deleting blob [key=%s]: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+
+	if err := checkResponse(resp); err != nil {
+		return fmt.Errorf("deleting blob [key=%s]: %w", key, err)
+	}
+
+	return nil
+}
+
+// URL returns the address the blob stored under the key is served at.
+func (s Store) URL(key string) string {
+	return s.cfg.PublicURL + "/" + escapePath(key)
+}
+
+// objectPath returns the path of the blob stored under the key.
+func (s Store) objectPath(key string) string {
+	return "/" + s.cfg.Bucket + "/" + key
+}
+
+// do sends a signed request for the path of the endpoint.
+func (s Store) do(ctx context.Context, method string, path string, contentType string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, s.cfg.Endpoint+escapePath(path), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	s.sign(req, body, time.Now().UTC())
+
+	return s.client.Do(req)
+}
+
+// =============================================================================
+
+const (
+	algorithm     = "AWS4-HMAC-SHA256"
+	service       = "s3"
+	signedHeaders = "host;x-amz-content-sha256;x-amz-date"
+)
+
+// sign adds the AWS Signature Version 4 authorization to the request.
+// https://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-header-based-auth.html
+func (s Store) sign(req *http.Request, body []byte, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := hashHex(body)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		"host:" + req.URL.Host,
+		"x-amz-content-sha256:" + payloadHash,
+		"x-amz-date:" + amzDate,
+		"",
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := strings.Join([]string{date, s.cfg.Region, service, "aws4_request"}, "/")
+
+	stringToSign := strings.Join([]string{
+		algorithm,
+		amzDate,
+		scope,
+		hashHex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.cfg.SecretKey), date)
+	key = hmacSHA256(key, s.cfg.Region)
+	key = hmacSHA256(key, service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		algorithm, s.cfg.AccessKey, scope, signedHeaders, signature))
+}
+
+// escapePath escapes every segment of the path the way Signature Version 4
+// expects: everything but the unreserved characters is percent encoded.
+func escapePath(path string) string {
+	const unreserved = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-._~/"
+
+	var b strings.Builder
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+		if strings.IndexByte(unreserved, c) >= 0 {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
+
+// checkResponse returns an error holding the body of unsuccessful responses,
+// where S3 describes the failure.
+func checkResponse(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("status %d: %s", resp.StatusCode, bytes.TrimSpace(msg))
+}
+
+func hashHex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
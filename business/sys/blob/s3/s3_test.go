@@ -0,0 +1,102 @@
+package s3_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/phbpx/gobeers/business/sys/blob"
+	"github.com/phbpx/gobeers/business/sys/blob/s3"
+	"github.com/phbpx/gobeers/foundation/docker"
+)
+
+var c *docker.Container
+
+func TestMain(m *testing.M) {
+	var err error
+	c, err = docker.StartContainer("bitnami/minio:latest", "9000",
+		"-e", "MINIO_ROOT_USER=minioadmin",
+		"-e", "MINIO_ROOT_PASSWORD=minioadmin",
+	)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer docker.StopContainer(c.ID)
+
+	m.Run()
+}
+
+func TestStore(t *testing.T) {
+	store := s3.NewStore(s3.Config{
+		Endpoint:  "http://" + c.Host,
+		Region:    "us-east-1",
+		Bucket:    "testblobs",
+		AccessKey: "minioadmin",
+		SecretKey: "minioadmin",
+	})
+
+	t.Log("Given the need to work with blobs on an S3 compatible storage.")
+	{
+		t.Logf("\tWhen handling a single blob.")
+		{
+			ctx := context.Background()
+
+			t.Log("Waiting for the storage to be ready ...")
+
+			var err error
+			for attempt := 0; attempt < 30; attempt++ {
+				if err = store.CreateBucket(ctx); err == nil {
+					break
+				}
+				time.Sleep(time.Second)
+			}
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to create a bucket : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should be able to create a bucket.")
+
+			key := "beers/test/image.png"
+			data := []byte("\x89PNG\r\n\x1a\ntest image")
+
+			if err := store.Put(ctx, key, "image/png", data); err != nil {
+				t.Fatalf("\t [ERROR] Should be able to put a blob : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should be able to put a blob.")
+
+			obj, err := store.Get(ctx, key)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to get a blob : %s", err)
+			}
+			defer obj.Body.Close()
+
+			got, err := io.ReadAll(obj.Body)
+			if err != nil {
+				t.Fatalf("\t [ERROR] Should be able to read a blob : %s", err)
+			}
+
+			if string(got) != string(data) || obj.ContentType != "image/png" {
+				t.Fatalf("\t [ERROR] Should get back the same blob : %q %s", got, obj.ContentType)
+			}
+			t.Logf("\t [SUCCESS] Should get back the same blob.")
+
+			if url := store.URL(key); url != "http://"+c.Host+"/testblobs/"+key {
+				t.Fatalf("\t [ERROR] Should get back the blob url : %s", url)
+			}
+			t.Logf("\t [SUCCESS] Should get back the blob url.")
+
+			if err := store.Delete(ctx, key); err != nil {
+				t.Fatalf("\t [ERROR] Should be able to delete a blob : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should be able to delete a blob.")
+
+			if _, err := store.Get(ctx, key); !errors.Is(err, blob.ErrNotFound) {
+				t.Fatalf("\t [ERROR] Should NOT be able to get a deleted blob : %s", err)
+			}
+			t.Logf("\t [SUCCESS] Should NOT be able to get a deleted blob.")
+		}
+	}
+}
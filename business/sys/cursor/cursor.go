@@ -0,0 +1,166 @@
+// Package cursor provides support for keyset pagination using opaque, signed
+// cursor tokens.
+package cursor
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/phbpx/gobeers/business/sys/order"
+	"github.com/phbpx/gobeers/business/sys/validate"
+)
+
+// ErrInvalid is returned when a token can't be decoded or its signature
+// doesn't match.
+var ErrInvalid = errors.New("invalid cursor")
+
+// Cursor marks a position in an ordered result set. It holds the ordering
+// used to produce the result set and the sort key values plus the id of the
+// row the position is relative to. A Cursor without an ID marks the start of
+// the result set.
+type Cursor struct {
+	OrderBy []order.By `json:"o"`
+	Values  []string   `json:"v,omitempty"`
+	ID      string     `json:"id,omitempty"`
+	Before  bool       `json:"b,omitempty"`
+}
+
+// IsStart reports whether the cursor marks the start of the result set.
+func (c Cursor) IsStart() bool {
+	return c.ID == ""
+}
+
+// Page holds the cursors pointing to the pages around a page of results.
+// A nil cursor means there is no page in that direction.
+type Page struct {
+	Next *Cursor
+	Prev *Cursor
+}
+
+// MaxSize is the maximum number of items in a page.
+const MaxSize = 100
+
+// CheckSize validates the size of a page, reported as an error on the size
+// field.
+func CheckSize(size int) error {
+	if size < 1 || size > MaxSize {
+		return validate.FieldErrors{{Field: "size", Error: fmt.Sprintf("size must be between 1 and %d", MaxSize)}}
+	}
+	return nil
+}
+
+// Paginate trims the extra item fetched past the page size and builds the
+// cursors to the pages around the returned items. When paging backwards the
+// items are expected in their natural order, with the extra item first. The
+// values function returns the sort key values and the id of an item.
+func Paginate[T any](items []T, cur Cursor, size int, values func(T, []order.By) ([]string, string)) ([]T, Page) {
+	hasMore := len(items) > size
+	if hasMore {
+		if cur.Before {
+			items = items[len(items)-size:]
+		} else {
+			items = items[:size]
+		}
+	}
+
+	if len(items) == 0 {
+		return items, Page{}
+	}
+
+	at := func(item T, before bool) *Cursor {
+		vals, id := values(item, cur.OrderBy)
+		return &Cursor{
+			OrderBy: cur.OrderBy,
+			Values:  vals,
+			ID:      id,
+			Before:  before,
+		}
+	}
+
+	var page Page
+	switch {
+	case cur.Before:
+		page.Next = at(items[len(items)-1], false)
+		if hasMore {
+			page.Prev = at(items[0], true)
+		}
+	default:
+		if hasMore {
+			page.Next = at(items[len(items)-1], false)
+		}
+		if !cur.IsStart() {
+			page.Prev = at(items[0], true)
+		}
+	}
+
+	return items, page
+}
+
+// =============================================================================
+
+// Signer encodes cursors into opaque tokens and decodes them back, using an
+// HMAC signature so clients can't tamper with them.
+type Signer struct {
+	key []byte
+}
+
+// NewSigner constructs a Signer for the provided key.
+func NewSigner(key string) Signer {
+	return Signer{
+		key: []byte(key),
+	}
+}
+
+// Encode converts the cursor into a signed token.
+func (s Signer) Encode(c Cursor) (string, error) {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return "", fmt.Errorf("encoding cursor: %w", err)
+	}
+
+	payload := base64.RawURLEncoding.EncodeToString(data)
+	signature := base64.RawURLEncoding.EncodeToString(s.sign(payload))
+
+	return payload + "." + signature, nil
+}
+
+// Decode validates the signature of the token and converts it back into a
+// cursor.
+func (s Signer) Decode(token string) (Cursor, error) {
+	payload, signature, found := strings.Cut(token, ".")
+	if !found {
+		return Cursor{}, ErrInvalid
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(sig, s.sign(payload)) {
+		return Cursor{}, ErrInvalid
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return Cursor{}, ErrInvalid
+	}
+
+	var c Cursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return Cursor{}, ErrInvalid
+	}
+
+	if !c.IsStart() && len(c.Values) != len(c.OrderBy) {
+		return Cursor{}, ErrInvalid
+	}
+
+	return c, nil
+}
+
+func (s Signer) sign(payload string) []byte {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
@@ -7,9 +7,11 @@ import (
 	"errors"
 	"net/url"
 	"runtime"
+	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/stdlib" // Calls init function.
 	"github.com/uptrace/bun"
 	"github.com/uptrace/bun/dialect/pgdialect"
@@ -103,12 +105,50 @@ func StatusCheck(ctx context.Context, db *bun.DB) error {
 //
 // https://www.postgresql.org/docs/current/static/errcodes-appendix.html
 func IsIntegrityViolation(err error) bool {
-	if pgErr, ok := err.(pgdriver.Error); ok && pgErr.IntegrityViolation() {
+	var pgxErr *pgconn.PgError
+	if errors.As(err, &pgxErr) {
+		return strings.HasPrefix(pgxErr.Code, "23")
+	}
+
+	var pgErr pgdriver.Error
+	if errors.As(err, &pgErr) && pgErr.IntegrityViolation() {
 		return true
 	}
+
 	return false
 }
 
+// IsUniqueViolation checks if the error is caused by a unique constraint
+// violation, code "23505". When constraint is not empty the violated
+// constraint, or unique index, must also have that name.
+func IsUniqueViolation(err error, constraint string) bool {
+	code, name := errorCode(err)
+	return code == "23505" && (constraint == "" || name == constraint)
+}
+
+// IsForeignKeyViolation checks if the error is caused by a foreign key
+// violation, code "23503".
+func IsForeignKeyViolation(err error) bool {
+	code, _ := errorCode(err)
+	return code == "23503"
+}
+
+// errorCode returns the SQLSTATE code of a database error along with the
+// name of the constraint it's about, if any.
+func errorCode(err error) (string, string) {
+	var pgxErr *pgconn.PgError
+	if errors.As(err, &pgxErr) {
+		return pgxErr.Code, pgxErr.ConstraintName
+	}
+
+	var pgErr pgdriver.Error
+	if errors.As(err, &pgErr) {
+		return pgErr.Field('C'), pgErr.Field('n')
+	}
+
+	return "", ""
+}
+
 // IsNoRowError checks if the error is caused by no row found in the database.
 func IsNoRowError(err error) bool {
 	return errors.Is(err, sql.ErrNoRows)
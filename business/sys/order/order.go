@@ -0,0 +1,76 @@
+// Package order provides support for describing the ordering of data.
+package order
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Set of directions for data ordering.
+const (
+	ASC  = "ASC"
+	DESC = "DESC"
+)
+
+// ErrInvalidField is returned when an order by expression references a field
+// that is not supported.
+var ErrInvalidField = errors.New("invalid order by field")
+
+// By represents a field used to order by and direction.
+type By struct {
+	Field     string
+	Direction string
+}
+
+// NewBy constructs a new By value with no checks.
+func NewBy(field string, direction string) By {
+	return By{
+		Field:     field,
+		Direction: direction,
+	}
+}
+
+// Parse constructs a list of By values from an order by expression. The
+// expression is a comma separated list of fields where a leading "-" means
+// descending order, for example "name,-created_at". If the expression is
+// empty the provided defaults are returned.
+func Parse(orderBy string, defaults ...By) ([]By, error) {
+	orderBy = strings.TrimSpace(orderBy)
+	if orderBy == "" {
+		return defaults, nil
+	}
+
+	var list []By
+	for _, field := range strings.Split(orderBy, ",") {
+		field = strings.TrimSpace(field)
+
+		direction := ASC
+		switch {
+		case strings.HasPrefix(field, "-"):
+			direction = DESC
+			field = field[1:]
+		case strings.HasPrefix(field, "+"):
+			field = field[1:]
+		}
+
+		if field == "" {
+			return nil, fmt.Errorf("%w: empty field in [%s]", ErrInvalidField, orderBy)
+		}
+
+		list = append(list, NewBy(field, direction))
+	}
+
+	return list, nil
+}
+
+// Check validates that every field in the list is part of the whitelist of
+// fields that can be ordered by.
+func Check(list []By, whitelist map[string]bool) error {
+	for _, by := range list {
+		if !whitelist[by.Field] {
+			return fmt.Errorf("%w: %s", ErrInvalidField, by.Field)
+		}
+	}
+	return nil
+}
@@ -36,6 +36,15 @@ func init() {
 	// Register the english error messages for use.
 	en_translations.RegisterDefaultTranslations(validate, translator)
 
+	// Register the english error message for fields required only when
+	// another field is missing, which the defaults don't cover.
+	validate.RegisterTranslation("required_without", translator, func(ut ut.Translator) error {
+		return ut.Add("required_without", "{0} is a required field", true)
+	}, func(ut ut.Translator, fe validator.FieldError) string {
+		t, _ := ut.T("required_without", fe.Field())
+		return t
+	})
+
 	// Use JSON tag names for errors instead of Go struct names.
 	validate.RegisterTagNameFunc(func(fld reflect.StructField) string {
 		name := strings.SplitN(fld.Tag.Get("json"), ",", 2)[0]
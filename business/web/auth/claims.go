@@ -12,9 +12,28 @@ import (
 type Claims struct {
 	jwt.RegisteredClaims
 
-	BusinessID string `json:"business_id" validate:"required,uuid"`
-	PersonID   string `json:"person_id" validate:"uuid"`
-	AppID      string `json:"azp" validate:"required"`
+	BusinessID string   `json:"business_id" validate:"required,uuid"`
+	PersonID   string   `json:"person_id" validate:"uuid"`
+	AppID      string   `json:"azp" validate:"required"`
+	Roles      []string `json:"roles"`
+}
+
+// Set of roles a user can be granted.
+const (
+	RoleModerator = "MODERATOR"
+	RoleAdmin     = "ADMIN"
+)
+
+// Authorized returns true if the claims has at least one of the provided roles.
+func (c Claims) Authorized(roles ...string) bool {
+	for _, has := range c.Roles {
+		for _, want := range roles {
+			if has == want {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 // Validate validates claims.
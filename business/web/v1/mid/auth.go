@@ -2,9 +2,11 @@ package mid
 
 import (
 	"context"
+	"errors"
 	"net/http"
 
 	"github.com/phbpx/gobeers/business/web/auth"
+	v1Web "github.com/phbpx/gobeers/business/web/v1"
 	"github.com/phbpx/gobeers/foundation/web"
 )
 
@@ -27,3 +29,22 @@ func Authenticate() web.Middleware {
 
 	return m
 }
+
+// Authorize validates that an authenticated user has at least one role from a
+// specified list. It must run after Authenticate.
+func Authorize(roles ...string) web.Middleware {
+	m := func(handler web.Handler) web.Handler {
+		h := func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+			claims := auth.GetClaims(ctx)
+			if !claims.Authorized(roles...) {
+				return v1Web.NewRequestError(errors.New("attempted action is not allowed"), http.StatusForbidden)
+			}
+
+			return handler(ctx, w, r)
+		}
+
+		return h
+	}
+
+	return m
+}
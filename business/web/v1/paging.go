@@ -0,0 +1,72 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/phbpx/gobeers/business/sys/cursor"
+)
+
+// CursorResponse is the form used for API responses paged with cursors.
+type CursorResponse struct {
+	Items any    `json:"items"`
+	Next  string `json:"next,omitempty"`
+	Prev  string `json:"prev,omitempty"`
+}
+
+// NewCursorResponse encodes the cursors of the page into tokens and sets the
+// RFC 8288 Link headers pointing at the next and previous pages.
+func NewCursorResponse(w http.ResponseWriter, r *http.Request, signer cursor.Signer, items any, page cursor.Page) (CursorResponse, error) {
+	resp := CursorResponse{
+		Items: items,
+	}
+
+	if page.Next != nil {
+		token, err := signer.Encode(*page.Next)
+		if err != nil {
+			return CursorResponse{}, err
+		}
+		resp.Next = token
+		addLink(w, linkURL(r, "cursor", token), "next")
+	}
+
+	if page.Prev != nil {
+		token, err := signer.Encode(*page.Prev)
+		if err != nil {
+			return CursorResponse{}, err
+		}
+		resp.Prev = token
+		addLink(w, linkURL(r, "cursor", token), "prev")
+	}
+
+	return resp, nil
+}
+
+// SetPageLinks sets the RFC 8288 Link headers pointing at the next and
+// previous pages for offset based paging. A next page is only advertised
+// when the current page is full.
+func SetPageLinks(w http.ResponseWriter, r *http.Request, page int, size int, count int) {
+	if count >= size {
+		addLink(w, linkURL(r, "page", strconv.Itoa(page+1)), "next")
+	}
+
+	if page > 1 {
+		addLink(w, linkURL(r, "page", strconv.Itoa(page-1)), "prev")
+	}
+}
+
+// linkURL returns the request URL with the query string key set to value.
+func linkURL(r *http.Request, key string, value string) string {
+	u := *r.URL
+
+	q := u.Query()
+	q.Set(key, value)
+	u.RawQuery = q.Encode()
+
+	return u.RequestURI()
+}
+
+func addLink(w http.ResponseWriter, url string, rel string) {
+	w.Header().Add("Link", fmt.Sprintf("<%s>; rel=%q", url, rel))
+}
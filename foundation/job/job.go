@@ -0,0 +1,27 @@
+// Package job provides support for running background work on an interval.
+package job
+
+import (
+	"context"
+	"time"
+)
+
+// Every runs fn right away and then once every interval until the context is
+// canceled. A failed run is reported to onErr and doesn't stop the job. Runs
+// never overlap: a run that takes longer than the interval delays the next.
+func Every(ctx context.Context, interval time.Duration, fn func(ctx context.Context) error, onErr func(err error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := fn(ctx); err != nil && ctx.Err() == nil {
+			onErr(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
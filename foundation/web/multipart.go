@@ -0,0 +1,103 @@
+package web
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Set of errors returned when decoding a file from a multipart form.
+var (
+	ErrFileTooLarge         = errors.New("file too large")
+	ErrUnsupportedMediaType = errors.New("unsupported media type")
+	ErrMissingFile          = errors.New("file missing from form")
+	ErrInvalidForm          = errors.New("invalid multipart form")
+)
+
+// formOverhead is the room left in the request body for the multipart
+// boundaries, part headers and the other fields of the form.
+const formOverhead = 64 << 10
+
+// File is a file uploaded in a multipart form. ContentType is sniffed from
+// the content of the file, the one declared by the client is ignored.
+type File struct {
+	Name        string
+	ContentType string
+	Data        []byte
+}
+
+// DecodeFile reads the file sent in the field of a multipart form request.
+// The file can't be larger than maxSize bytes and, when provided, its content
+// type must be one of the allowed. The form is streamed and only the file is
+// kept in memory.
+func DecodeFile(w http.ResponseWriter, r *http.Request, field string, maxSize int64, allowed ...string) (File, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxSize+formOverhead)
+
+	mr, err := r.MultipartReader()
+	if err != nil {
+		return File{}, fmt.Errorf("%w: %s", ErrInvalidForm, err)
+	}
+
+	for {
+		part, err := mr.NextPart()
+		if err != nil {
+			var maxErr *http.MaxBytesError
+			switch {
+			case errors.Is(err, io.EOF):
+				return File{}, ErrMissingFile
+			case errors.As(err, &maxErr):
+				return File{}, ErrFileTooLarge
+			default:
+				return File{}, fmt.Errorf("%w: %s", ErrInvalidForm, err)
+			}
+		}
+
+		if part.FormName() != field {
+			part.Close()
+			continue
+		}
+		defer part.Close()
+
+		var buf bytes.Buffer
+		n, err := buf.ReadFrom(io.LimitReader(part, maxSize+1))
+		if err != nil {
+			var maxErr *http.MaxBytesError
+			if errors.As(err, &maxErr) {
+				return File{}, ErrFileTooLarge
+			}
+			return File{}, fmt.Errorf("%w: reading file %q: %s", ErrInvalidForm, field, err)
+		}
+
+		switch {
+		case n == 0:
+			return File{}, ErrMissingFile
+		case n > maxSize:
+			return File{}, ErrFileTooLarge
+		}
+
+		// DetectContentType considers at most the first 512 bytes.
+		file := File{
+			Name:        part.FileName(),
+			ContentType: http.DetectContentType(buf.Bytes()),
+			Data:        buf.Bytes(),
+		}
+
+		if len(allowed) > 0 && !contains(allowed, file.ContentType) {
+			return File{}, fmt.Errorf("%w: %s", ErrUnsupportedMediaType, file.ContentType)
+		}
+
+		return file, nil
+	}
+}
+
+// contains reports whether v is in list.
+func contains(list []string, v string) bool {
+	for _, item := range list {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}